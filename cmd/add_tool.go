@@ -4,12 +4,26 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/hooneun/aide/internal/storage"
 )
 
+// addToolFormat은 새 도구의 파일 형식입니다
+var addToolFormat string
+
+// addToolForce는 기본 제공 도구를 재정의할지 여부입니다
+var addToolForce bool
+
+// 디렉터리 출력 설정
+var (
+	addToolOutput    string // 출력 방식 (file, directory)
+	addToolPattern   string // 규칙 파일 이름 패턴
+	addToolExtension string // 규칙 파일 확장자
+)
+
 // addToolCmd는 새로운 도구를 추가하는 명령어를 나타냅니다
 var addToolCmd = &cobra.Command{
 	Use:   "add-tool <도구명> <파일명> <파일설명>",
@@ -17,41 +31,122 @@ var addToolCmd = &cobra.Command{
 	Long: `새로운 AI 도구를 aide에 추가합니다.
 도구명, 생성할 파일명, 파일설명을 입력받아 저장합니다.
 
+--format으로 파일 형식을 지정할 수 있습니다. 지정하지 않으면 확장자로 판단합니다.
+  text   aide 영역으로 프롬프트를 추가합니다 (기본값)
+  json   프롬프트를 JSON 객체로 보고 기존 문서에 깊게 병합합니다 (.json 파일)
+  yaml   프롬프트를 YAML 매핑으로 보고 기존 문서에 깊게 병합합니다 (.yml, .yaml 파일)
+  toml   프롬프트를 TOML 테이블로 보고 기존 문서에 깊게 병합합니다 (.toml 파일)
+
+--output directory를 지정하거나 파일명이 '/'로 끝나면 파일명을 디렉터리로 보고,
+적용하는 카테고리마다 그 아래에 규칙 파일 하나를 만듭니다.
+파일 이름은 --pattern({category}, {tool} 치환)과 --ext로 정합니다.
+
+기본 제공 도구(claude, cursor, windsurf, cline, aider 등)와 같은 이름은 기본 설정을 재정의하므로
+--force를 지정해야 합니다.
+
 예시:
   aide add-tool vscode .vscode/settings.json "VS Code 설정 파일"
-  aide add-tool windsurf .windsurfrules "Windsurf 규칙 파일"`,
+  aide add-tool zed .rules "Zed 규칙 파일"
+  aide add-tool aider-conf .aider.conf.yml "aider 설정 파일"
+  aide add-tool roo .roo/rules/ "Roo Code 규칙 디렉터리"
+  aide add-tool continue .continue/rules/ "Continue 규칙" --pattern "aide-{category}" --ext .md`,
 	Args: cobra.ExactArgs(3),
 	Run: func(cmd *cobra.Command, args []string) {
 		toolName := args[0]
 		fileName := args[1]
 		description := args[2]
 
-		storage, err := storage.New()
+		if err := storage.ValidateToolName(toolName); err != nil {
+			fmt.Printf("오류: %v\n", err)
+			os.Exit(1)
+		}
+
+		store, err := openUserStore()
+		if err != nil {
+			fmt.Printf("오류: %v\n", err)
+			os.Exit(1)
+		}
+
+		format, err := resolveToolFormat(addToolFormat, fileName)
+		if err != nil {
+			fmt.Printf("오류: %v\n", err)
+			os.Exit(1)
+		}
+
+		output, err := resolveToolOutput(addToolOutput, fileName)
 		if err != nil {
-			fmt.Printf("오류: 저장소를 초기화할 수 없습니다: %v\n", err)
+			fmt.Printf("오류: %v\n", err)
 			os.Exit(1)
 		}
 
-		// 대화형으로 추가 설정 입력받기
-		reader := bufio.NewReader(os.Stdin)
+		config := storage.ToolConfig{
+			Name:        toolName,
+			FileName:    strings.TrimRight(fileName, "/"),
+			Description: description,
+			Format:      format,
+		}
+
+		// 기본 제공 도구를 모르고 덮어쓰지 않도록 처음 재정의할 때는 --force 필요
+		if existing, err := resolveTool(toolName); err == nil && existing.Builtin {
+			if !existing.Overridden && !addToolForce {
+				fmt.Printf("오류: '%s'는 기본 제공 도구입니다 (%s). 재정의하려면 --force를 지정하고, 새 도구라면 다른 이름을 사용하세요\n", toolName, existing.FileName)
+				os.Exit(1)
+			}
+			fmt.Printf("기본 제공 도구 '%s'의 설정을 재정의합니다.\n", toolName)
+		}
 
 		fmt.Printf("새로운 도구 '%s' 설정:\n", toolName)
 		fmt.Printf("파일명: %s\n", fileName)
 		fmt.Printf("설명: %s\n", description)
-		fmt.Print("파일 헤더 (선택사항, 엔터로 건너뛰기): ")
-		
-		header, _ := reader.ReadString('\n')
-		header = strings.TrimSpace(header)
-
-		fmt.Print("파일 구분자 (기본값: '# ---'): ")
-		separator, _ := reader.ReadString('\n')
-		separator = strings.TrimSpace(separator)
-		if separator == "" {
-			separator = "# ---"
+		fmt.Printf("형식: %s\n", format)
+
+		if output == storage.OutputDirectory {
+			if format != storage.FormatText {
+				fmt.Printf("오류: 디렉터리 출력은 text 형식만 지원합니다: %s\n", format)
+				os.Exit(1)
+			}
+			if addToolPattern != "" && !strings.Contains(addToolPattern, "{category}") {
+				fmt.Printf("오류: 파일 이름 패턴에 {category}가 있어야 합니다: %s\n", addToolPattern)
+				os.Exit(1)
+			}
+
+			config.Output = output
+			config.FilePattern = addToolPattern
+			config.Extension = addToolExtension
+
+			pattern, extension := addToolPattern, addToolExtension
+			if pattern == "" {
+				pattern = storage.DefaultFilePattern
+			}
+			if extension == "" {
+				extension = storage.DefaultExtension
+			}
+			fmt.Printf("출력: 디렉터리 (%s/%s%s)\n", config.FileName, pattern, extension)
+		}
+
+		// 텍스트 형식일 때만 대화형으로 헤더와 구분자 입력받기
+		if format == storage.FormatText {
+			reader := bufio.NewReader(os.Stdin)
+
+			fmt.Print("파일 헤더 (선택사항, 엔터로 건너뛰기): ")
+			header, _ := reader.ReadString('\n')
+			config.Header = strings.TrimSpace(header)
+
+			// 디렉터리 출력은 구분자 없이 Markdown 주석을 기본으로 사용
+			if output == storage.OutputDirectory {
+				fmt.Print("파일 구분자 (선택사항, 엔터로 건너뛰면 Markdown 주석 사용): ")
+			} else {
+				fmt.Print("파일 구분자 (기본값: '# ---'): ")
+			}
+			separator, _ := reader.ReadString('\n')
+			config.Separator = strings.TrimSpace(separator)
+			if config.Separator == "" && output != storage.OutputDirectory {
+				config.Separator = "# ---"
+			}
 		}
 
 		// 도구 설정 저장
-		err = storage.SaveToolConfig(toolName, fileName, description, header, separator)
+		err = store.SaveToolConfig(config)
 		if err != nil {
 			fmt.Printf("오류: 도구 설정을 저장할 수 없습니다: %v\n", err)
 			os.Exit(1)
@@ -62,6 +157,47 @@ var addToolCmd = &cobra.Command{
 	},
 }
 
+// resolveToolFormat은 지정된 형식을 검증하거나, 비어 있으면 파일 확장자로 형식을 정합니다
+func resolveToolFormat(format, fileName string) (string, error) {
+	switch format {
+	case storage.FormatText, storage.FormatJSON, storage.FormatYAML, storage.FormatTOML:
+		return format, nil
+	case "":
+		switch strings.ToLower(filepath.Ext(fileName)) {
+		case ".json":
+			return storage.FormatJSON, nil
+		case ".yml", ".yaml":
+			return storage.FormatYAML, nil
+		case ".toml":
+			return storage.FormatTOML, nil
+		default:
+			return storage.FormatText, nil
+		}
+	default:
+		return "", fmt.Errorf("지원되지 않는 파일 형식입니다: %s (text, json, yaml, toml 중 하나)", format)
+	}
+}
+
+// resolveToolOutput은 지정된 출력 방식을 검증하거나, 비어 있으면 파일명이 '/'로 끝나는지로 정합니다
+func resolveToolOutput(output, fileName string) (string, error) {
+	switch output {
+	case storage.OutputFile, storage.OutputDirectory:
+		return output, nil
+	case "":
+		if strings.HasSuffix(fileName, "/") {
+			return storage.OutputDirectory, nil
+		}
+		return storage.OutputFile, nil
+	default:
+		return "", fmt.Errorf("지원되지 않는 출력 방식입니다: %s (file, directory 중 하나)", output)
+	}
+}
+
 func init() {
+	addToolCmd.Flags().StringVar(&addToolFormat, "format", "", "파일 형식 (text, json, yaml, toml)")
+	addToolCmd.Flags().StringVar(&addToolOutput, "output", "", "출력 방식 (file, directory)")
+	addToolCmd.Flags().StringVar(&addToolPattern, "pattern", "", "디렉터리 출력의 파일 이름 패턴 (기본값: {category})")
+	addToolCmd.Flags().StringVar(&addToolExtension, "ext", "", "디렉터리 출력의 파일 확장자 (기본값: .md)")
+	addToolCmd.Flags().BoolVarP(&addToolForce, "force", "f", false, "기본 제공 도구를 재정의")
 	rootCmd.AddCommand(addToolCmd)
 }
\ No newline at end of file
@@ -1,109 +1,461 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/hooneun/aide/internal/config"
+	"github.com/hooneun/aide/internal/diff"
 	"github.com/hooneun/aide/internal/generators"
+	"github.com/hooneun/aide/internal/manifest"
+	"github.com/hooneun/aide/internal/query"
+	"github.com/hooneun/aide/internal/registry"
 	"github.com/hooneun/aide/internal/storage"
 
 	"github.com/spf13/cobra"
 )
 
+// applyDryRun은 파일을 쓰지 않고 변경 내용만 보여줄지 여부입니다
+var applyDryRun bool
+
+var (
+	// applyTags는 적용할 프롬프트를 고를 태그 목록입니다 (하나라도 있으면 선택)
+	applyTags []string
+	// applyQuery는 적용할 프롬프트를 고를 쿼리 식입니다
+	applyQuery string
+	// applyYes는 태그/쿼리로 고른 프롬프트를 확인 없이 적용할지 여부입니다
+	applyYes bool
+)
+
 // applyCmd는 프롬프트를 현재 프로젝트에 적용하는 명령어입니다
 var applyCmd = &cobra.Command{
-	Use:   "apply <도구> <카테고리>[,카테고리2,...]",
+	Use:   "apply <도구> [카테고리[,카테고리2,...]]",
 	Short: "프롬프트를 현재 프로젝트에 적용합니다",
 	Long: `저장된 프롬프트를 현재 프로젝트에 적용합니다.
-해당 도구의 설정 파일을 생성하거나 내용을 추가합니다.
+해당 도구의 설정 파일을 생성하거나, 카테고리별 aide 영역을 추가합니다.
+이미 적용된 카테고리는 해당 영역만 제자리에서 교체되며, 영역 바깥의 내용은 그대로 유지됩니다.
+
+--dry-run을 지정하면 파일을 쓰지 않고 변경될 내용을 unified diff로 출력합니다.
+변경 사항이 있으면 종료 코드 2로 끝납니다.
+
+프롬프트는 Go text/template으로 렌더링한 뒤 적용합니다 ({{.이름}}으로 변수 참조).
+변수는 다음 순서로 찾으며, 정의되지 않은 변수를 참조하면 모두 나열하고 적용하지 않습니다.
+  --var 이름=값               명령줄 (여러 번 지정 가능)
+  .aide.yaml의 vars           프로젝트 매니페스트
+  AIDE_VAR_<이름>             환경 변수
+  Module, Repo, Language      go.mod의 모듈 경로, 저장소 이름, 주 언어 (자동으로 찾음)
+  Tool, Category              적용 중인 도구와 카테고리
+
+카테고리 대신 --tag나 --query로 메타데이터와 일치하는 프롬프트(공유 프롬프트 포함)를 모두 고를 수 있습니다.
+고른 카테고리를 먼저 보여주고 확인한 뒤 적용하며, --yes를 지정하면 확인하지 않습니다.
+쿼리 문법:
+  go                  go 태그가 있는 프롬프트 (tag:go와 같음)
+  category:go/*       카테고리 패턴 (cat:도 가능, category:go는 go 아래 카테고리 포함)
+  desc:보안           설명에 단어가 포함된 프롬프트
+  and, or, not, ()    조건 조합 (공백으로 이어진 조건은 and)
 
 예시:
   aide apply claude review                    # Claude 리뷰 프롬프트 적용
   aide apply cursor backend                   # Cursor 백엔드 프롬프트 적용
-  aide apply cursor backend,frontend          # 여러 프롬프트 동시 적용`,
-	Args: cobra.ExactArgs(2),
+  aide apply cursor backend,frontend          # 여러 프롬프트 동시 적용
+  aide apply claude review --dry-run          # 적용하지 않고 변경 내용만 확인
+  aide apply claude review --var team=payments  # 템플릿 변수 지정
+  aide apply claude --tag go,security         # go 또는 security 태그가 있는 프롬프트 적용
+  aide apply claude -q "go and not legacy" -y # 쿼리로 고른 프롬프트를 확인 없이 적용`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		tool := args[0]
-		categoriesArg := args[1]
-
-		// 설정 초기화
-		cfg, err := config.New()
+		selector, err := applySelector()
 		if err != nil {
-			return fmt.Errorf("설정을 초기화할 수 없습니다: %w", err)
+			return err
 		}
 
-		// 지원되는 도구인지 확인
-		if err := cfg.ValidateTool(tool); err != nil {
-			return err
+		categoriesArg := ""
+		switch {
+		case len(args) == 2 && selector != nil:
+			return fmt.Errorf("카테고리와 --tag/--query는 함께 지정할 수 없습니다")
+		case len(args) == 2:
+			categoriesArg = args[1]
+		case selector == nil:
+			return fmt.Errorf("적용할 카테고리나 --tag/--query를 지정하세요")
 		}
 
-		// 저장소 초기화
-		store, err := storage.New()
+		plan, err := newApplyPlan(args[0], categoriesArg, selector)
 		if err != nil {
-			return fmt.Errorf("저장소를 초기화할 수 없습니다: %w", err)
+			return err
 		}
 
-		// 카테고리 목록 파싱
-		categories := strings.Split(categoriesArg, ",")
-		for i, category := range categories {
-			categories[i] = strings.TrimSpace(category)
+		// 태그/쿼리로 고른 카테고리는 먼저 보여줌
+		if selector != nil {
+			fmt.Printf("쿼리 '%s'와 일치하는 프롬프트 %d개:\n", selector, len(plan.statuses))
+			plan.printStatuses()
 		}
 
-		// 각 카테고리에 대해 프롬프트 가져오기
-		var prompts []string
-		for _, category := range categories {
-			if category == "" {
-				continue
-			}
+		if applyDryRun {
+			return plan.printDiff(cmd)
+		}
 
-			prompt, err := store.GetPrompt(tool, category)
-			if err != nil {
-				return fmt.Errorf("프롬프트를 가져오는 중 오류가 발생했습니다: %w", err)
+		if len(plan.sections) == 0 {
+			if selector == nil {
+				plan.printStatuses()
 			}
+			fmt.Printf("모든 프롬프트가 이미 %s에 적용되어 있습니다.\n", plan.targetFile)
+			return nil
+		}
+
+		if selector != nil && !applyYes && !confirm(fmt.Sprintf("%s에 적용하시겠습니까?", displayPath(plan.targetFile))) {
+			fmt.Println("적용을 취소했습니다.")
+			return nil
+		}
+
+		// 프롬프트 적용
+		if err := generators.WriteChanges(plan.changes); err != nil {
+			return fmt.Errorf("프롬프트를 적용하는 중 오류가 발생했습니다: %w", err)
+		}
 
-			prompts = append(prompts, prompt)
+		if selector == nil {
+			plan.printStatuses()
 		}
+		fmt.Printf("프롬프트가 %s에 성공적으로 적용되었습니다.\n", plan.targetFile)
 
-		if len(prompts) == 0 {
-			return fmt.Errorf("적용할 프롬프트가 없습니다")
+		return nil
+	},
+}
+
+// applyPlan은 apply, diff, sync가 공유하는 적용 준비 결과입니다
+type applyPlan struct {
+	tool       string
+	targetFile string // 대상 파일 또는 규칙 디렉터리
+	generator  generators.Generator
+	statuses   []generators.SectionStatus // 카테고리별 적용 상태
+	sections   []generators.Section       // 실제로 반영할 섹션 (이미 적용된 것은 제외)
+	stale      []string                   // 선언되지 않아 제거할 카테고리 (sync에서만 사용)
+	changes    []generators.FileChange    // 반영했을 때의 파일 변경
+}
+
+// newApplyPlan은 도구와 카테고리 인자로 프롬프트와 대상 파일, 생성기를 준비합니다.
+// selector가 있으면 카테고리 인자 대신 쿼리와 일치하는 프롬프트를 모두 사용합니다.
+func newApplyPlan(tool, categoriesArg string, selector query.Expr) (*applyPlan, error) {
+	// 지원되는 도구인지 확인
+	toolDef, err := resolveTool(tool)
+	if err != nil {
+		return nil, err
+	}
+
+	// 대상 파일 경로 가져오기
+	targetFile, err := toolTarget(toolDef)
+	if err != nil {
+		return nil, err
+	}
+
+	// 현재 프로젝트 기준 계층화된 저장소
+	layers, err := openCurrentLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := currentTemplateVars()
+	if err != nil {
+		return nil, err
+	}
+
+	categories := splitCategories(categoriesArg)
+	if selector != nil {
+		if categories, err = selectCategories(layers, toolDef.Name, selector); err != nil {
+			return nil, err
 		}
+	}
 
-		// 대상 파일 경로 가져오기
-		targetFile, err := cfg.GetTargetFile(tool)
+	return planTool(layers, vars, toolDef, targetFile, categories, false)
+}
+
+// currentTemplateVars는 현재 디렉터리 기준 템플릿 변수를 모읍니다.
+// 매니페스트가 있으면 매니페스트의 vars를 사용하고 매니페스트 디렉터리에서 프로젝트 정보를 찾습니다.
+func currentTemplateVars() (*promptVars, error) {
+	m, err := loadManifest("")
+	if err != nil {
+		if !errors.Is(err, manifest.ErrNotFound) {
+			return nil, err
+		}
+		m = nil
+	}
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("현재 디렉터리를 가져올 수 없습니다: %w", err)
+	}
+	if m != nil {
+		projectDir = m.Dir
+	}
+	return templateVars(projectDir, m)
+}
+
+// applySelector는 --tag와 --query로 프롬프트를 고를 식을 만듭니다. 둘 다 없으면 nil을 반환합니다.
+func applySelector() (query.Expr, error) {
+	var tags, parsed query.Expr
+	if len(storage.NormalizeTags(applyTags)) > 0 {
+		tags = query.AnyTag(applyTags)
+	}
+	if applyQuery != "" {
+		var err error
+		if parsed, err = query.Parse(applyQuery); err != nil {
+			return nil, err
+		}
+	}
+	return query.All(tags, parsed), nil
+}
+
+// selectCategories는 도구에 적용할 수 있는 프롬프트 중 쿼리와 일치하는 카테고리를 이름순으로 반환합니다
+func selectCategories(layers *storage.Layers, tool string, selector query.Expr) ([]string, error) {
+	origins, err := layers.ListPrompts(tool)
+	if err != nil {
+		return nil, fmt.Errorf("프롬프트 목록을 가져올 수 없습니다: %w", err)
+	}
+
+	var categories []string
+	for category, origin := range origins {
+		meta, err := layers.GetMeta(origin, category)
 		if err != nil {
-			return fmt.Errorf("대상 파일을 결정할 수 없습니다: %w", err)
+			return nil, err
+		}
+		if selector.Match(category, meta) {
+			categories = append(categories, category)
 		}
+	}
+	if len(categories) == 0 {
+		return nil, fmt.Errorf("쿼리 '%s'와 일치하는 %s 프롬프트가 없습니다", selector, tool)
+	}
 
-		// 파일 생성기 생성
-		generator, err := generators.NewGenerator(tool)
+	sort.Strings(categories)
+	return categories, nil
+}
+
+// planTool은 도구의 대상에 카테고리 프롬프트를 반영하는 변경을 계산합니다.
+// prune이면 대상에 적용되어 있지만 categories에 없는 카테고리도 제거합니다.
+func planTool(layers *storage.Layers, vars *promptVars, toolDef *registry.Tool, targetFile string, categories []string, prune bool) (*applyPlan, error) {
+	// 각 카테고리에 대해 프롬프트 가져오기
+	var sections []generators.Section
+	for _, category := range categories {
+		section, err := resolveSection(layers, vars, toolDef.Name, category)
 		if err != nil {
-			return fmt.Errorf("파일 생성기를 초기화할 수 없습니다: %w", err)
+			return nil, fmt.Errorf("프롬프트를 가져오는 중 오류가 발생했습니다: %w", err)
 		}
 
-		// 중복 프롬프트 확인
-		uniquePrompts, err := generators.CheckDuplicatePrompts(targetFile, prompts)
+		sections = append(sections, section)
+	}
+
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("적용할 프롬프트가 없습니다")
+	}
+
+	// 파일 생성기 생성
+	generator, err := generators.NewGenerator(toolDef)
+	if err != nil {
+		return nil, fmt.Errorf("파일 생성기를 초기화할 수 없습니다: %w", err)
+	}
+
+	// 적용된 해시와 비교하여 카테고리별 상태 판단
+	statuses, err := generator.Classify(targetFile, sections)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []generators.Section
+	for _, status := range statuses {
+		if status.Status != generators.StatusUnchanged {
+			pending = append(pending, status.Section)
+		}
+	}
+
+	// 선언되지 않은 카테고리 찾기
+	var stale []generators.Section
+	var staleNames []string
+	if prune {
+		applied, err := generator.Applied(targetFile)
 		if err != nil {
-			return fmt.Errorf("중복 프롬프트를 확인하는 중 오류가 발생했습니다: %w", err)
+			return nil, err
 		}
+		for _, category := range applied {
+			if !containsString(categories, category) {
+				// 저장소에서 지운 프롬프트도 파일에서는 제거할 수 있음
+				section, err := resolveSection(layers, vars, toolDef.Name, category)
+				if errors.Is(err, storage.ErrPromptNotFound) {
+					if _, _, findErr := layers.ResolvePrompt(toolDef.Name, category); errors.Is(findErr, storage.ErrPromptNotFound) {
+						section, err = generators.Section{Category: category}, nil
+					}
+				}
+				if err != nil {
+					return nil, fmt.Errorf("제거할 프롬프트를 가져오는 중 오류가 발생했습니다: %w", err)
+				}
+				stale = append(stale, section)
+				staleNames = append(staleNames, category)
+			}
+		}
+	}
 
-		if len(uniquePrompts) == 0 {
-			fmt.Printf("모든 프롬프트가 이미 %s에 적용되어 있습니다.\n", targetFile)
-			return nil
+	// 반영하거나 제거할 섹션이 있을 때만 변경 계산
+	var changes []generators.FileChange
+	if len(pending) > 0 || len(stale) > 0 {
+		if changes, err = generator.Reconcile(targetFile, pending, stale); err != nil {
+			return nil, err
 		}
+	}
 
-		// 프롬프트 적용
-		if err := generator.Generate(targetFile, uniquePrompts); err != nil {
-			return fmt.Errorf("프롬프트를 적용하는 중 오류가 발생했습니다: %w", err)
+	return &applyPlan{
+		tool:       toolDef.Name,
+		targetFile: targetFile,
+		generator:  generator,
+		statuses:   statuses,
+		sections:   pending,
+		stale:      staleNames,
+		changes:    changes,
+	}, nil
+}
+
+// printStatuses는 카테고리별 적용 상태를 출력합니다
+func (p *applyPlan) printStatuses() {
+	for _, status := range p.statuses {
+		mark := "+"
+		switch status.Status {
+		case generators.StatusChanged:
+			mark = "~"
+		case generators.StatusUnchanged:
+			mark = "="
+		case generators.StatusModified:
+			mark = "!"
 		}
+		fmt.Printf("  %s %s: %s\n", mark, status.Category, status.Status)
+	}
+	for _, category := range p.stale {
+		fmt.Printf("  - %s: 제거\n", category)
+	}
+}
 
-		fmt.Printf("프롬프트가 %s에 성공적으로 적용되었습니다.\n", targetFile)
-		fmt.Printf("적용된 카테고리: %s\n", strings.Join(categories, ", "))
+// printDiff는 적용 시 바뀔 내용을 unified diff로 출력합니다.
+// 변경 사항이 있으면 exitChangesPending 종료 코드를 반환합니다.
+func (p *applyPlan) printDiff(cmd *cobra.Command) error {
+	if !printChanges(p.changes) {
+		fmt.Printf("%s에 변경 사항이 없습니다.\n", displayPath(p.targetFile))
+		return nil
+	}
+	return exitWithCode(cmd, exitChangesPending)
+}
 
+// printChanges는 파일 변경을 unified diff로 출력하고, 출력한 변경이 있는지 반환합니다
+func printChanges(changes []generators.FileChange) bool {
+	printed := false
+	for _, change := range changes {
+		if !change.Changed() || change.Internal {
+			continue
+		}
+
+		name := displayPath(change.Path)
+		oldName, newName := "a/"+name, "b/"+name
+		if !change.Exists {
+			oldName = "/dev/null"
+		}
+		if change.Delete {
+			newName = "/dev/null"
+		}
+
+		fmt.Print(diff.Unified(oldName, newName, change.Before, change.After))
+		printed = true
+	}
+	return printed
+}
+
+// displayPath는 현재 디렉터리 기준 상대 경로를 반환합니다
+func displayPath(path string) string {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(currentDir, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// resolveTool은 레지스트리에서 도구 정의를 찾습니다
+func resolveTool(name string) (*registry.Tool, error) {
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return reg.Get(name)
+}
+
+// resolveSection은 계층화된 저장소에서 프롬프트와 메타데이터를 찾아 적용할 섹션을 만듭니다.
+// 포함과 상속을 펼치고, --raw로 표시하지 않은 프롬프트는 vars로 템플릿을 렌더링합니다.
+func resolveSection(layers *storage.Layers, vars *promptVars, tool, category string) (generators.Section, error) {
+	prompt, origin, err := layers.ExpandPrompt(tool, category)
+	if err != nil {
+		return generators.Section{}, err
+	}
+	meta, err := layers.GetMeta(origin, category)
+	if err != nil {
+		return generators.Section{}, err
+	}
+	if !meta.Raw {
+		if prompt, err = renderPrompt(tool, category, prompt, vars); err != nil {
+			return generators.Section{}, err
+		}
+	}
+	return generators.Section{
+		Category:    category,
+		Prompt:      prompt,
+		Description: meta.Description,
+		Globs:       meta.Globs,
+	}, nil
+}
+
+// checkPromptTool은 프롬프트를 저장할 수 있는 도구(또는 공유 네임스페이스)인지 확인합니다
+func checkPromptTool(name string) error {
+	if name == storage.SharedTool {
 		return nil
-	},
+	}
+	_, err := resolveTool(name)
+	return err
+}
+
+// toolTarget은 현재 프로젝트에서 도구의 대상 경로를 반환합니다
+func toolTarget(tool *registry.Tool) (string, error) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("현재 디렉터리를 가져올 수 없습니다: %w", err)
+	}
+	return tool.Target(currentDir), nil
+}
+
+// containsString은 목록에 값이 있는지 확인합니다
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCategories는 쉼표로 구분된 카테고리 목록을 파싱합니다
+func splitCategories(arg string) []string {
+	var categories []string
+	for _, category := range strings.Split(arg, ",") {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
+		}
+	}
+	return categories
 }
 
 func init() {
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "파일을 쓰지 않고 변경 내용을 diff로 출력")
+	applyCmd.Flags().StringSliceVar(&applyTags, "tag", nil, "태그가 하나라도 있는 프롬프트 적용 (쉼표로 구분)")
+	applyCmd.Flags().StringVarP(&applyQuery, "query", "q", "", "쿼리와 일치하는 프롬프트 적용 (예: \"go and not legacy\")")
+	applyCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "태그/쿼리로 고른 프롬프트를 확인 없이 적용")
+	addVarFlag(applyCmd)
 	rootCmd.AddCommand(applyCmd)
 }
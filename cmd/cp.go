@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hooneun/aide/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// cpForce는 대상 프롬프트를 확인 없이 덮어쓸지 여부입니다
+var cpForce bool
+
+// cpScope는 프롬프트를 다룰 저장소 범위입니다
+var cpScope string
+
+// cpCmd는 프롬프트를 다른 도구나 카테고리로 복사하는 명령어입니다
+var cpCmd = &cobra.Command{
+	Use:   "cp <도구> <카테고리> <대상 도구> [대상 카테고리]",
+	Short: "프롬프트를 다른 도구나 카테고리로 복사합니다",
+	Long: `프롬프트를 다른 도구나 카테고리로 복사합니다.
+대상 카테고리를 생략하면 같은 카테고리 이름을 사용합니다.
+대상에 프롬프트가 이미 있으면 덮어쓰기 전에 확인을 묻습니다.
+
+예시:
+  aide cp claude review cursor              # claude/review -> cursor/review
+  aide cp claude review claude review-v2    # 같은 도구 안에서 복사
+  aide cp claude review agents --force      # 확인 없이 덮어쓰기`,
+	Args: cobra.RangeArgs(3, 4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcTool, srcCategory := args[0], args[1]
+		dstTool, dstCategory := args[2], args[1]
+		if len(args) == 4 {
+			dstCategory = args[3]
+		}
+
+		// 지원되는 도구인지 확인
+		for _, tool := range []string{srcTool, dstTool} {
+			if err := checkPromptTool(tool); err != nil {
+				return err
+			}
+		}
+
+		// 저장소 초기화
+		store, err := openScope(cpScope)
+		if err != nil {
+			return err
+		}
+
+		err = store.CopyPrompt(srcTool, srcCategory, dstTool, dstCategory, cpForce)
+		if errors.Is(err, storage.ErrPromptExists) {
+			if !confirm(fmt.Sprintf("%s/%s에 프롬프트가 이미 있습니다. 덮어쓸까요?", dstTool, dstCategory)) {
+				fmt.Println("취소되었습니다.")
+				return nil
+			}
+			err = store.CopyPrompt(srcTool, srcCategory, dstTool, dstCategory, true)
+		}
+		if err != nil {
+			return fmt.Errorf("프롬프트를 복사하는 중 오류가 발생했습니다: %w", err)
+		}
+
+		fmt.Printf("프롬프트가 복사되었습니다: %s/%s -> %s/%s\n", srcTool, srcCategory, dstTool, dstCategory)
+		return nil
+	},
+}
+
+func init() {
+	cpCmd.Flags().BoolVarP(&cpForce, "force", "f", false, "대상 프롬프트를 확인 없이 덮어쓰기")
+	addScopeFlag(cpCmd, &cpScope)
+	rootCmd.AddCommand(cpCmd)
+}
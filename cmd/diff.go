@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// diffCmd는 프롬프트를 적용했을 때의 변경 내용을 보여주는 명령어입니다
+var diffCmd = &cobra.Command{
+	Use:   "diff <도구> <카테고리>[,카테고리2,...]",
+	Short: "프롬프트 적용 시 변경될 내용을 diff로 보여줍니다",
+	Long: `'aide apply'와 같은 과정을 메모리에서 실행하고, 대상 파일에 생길 변경 내용을
+unified diff 형식으로 출력합니다. 파일은 수정하지 않습니다.
+
+변경 사항이 있으면 종료 코드 2로 끝나므로 CI에서 적용 누락을 확인하는 데 사용할 수 있습니다.
+
+예시:
+  aide diff claude review                     # Claude 리뷰 프롬프트 변경 내용 확인
+  aide diff cursor backend,frontend           # 여러 프롬프트 변경 내용 확인`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := newApplyPlan(args[0], args[1], nil)
+		if err != nil {
+			return err
+		}
+		return plan.printDiff(cmd)
+	},
+}
+
+func init() {
+	addVarFlag(diffCmd)
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hooneun/aide/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultEditor는 $VISUAL과 $EDITOR가 모두 비어 있을 때 사용하는 편집기입니다
+const defaultEditor = "vi"
+
+// editScope는 프롬프트를 다룰 저장소 범위입니다
+var editScope string
+
+// editCmd는 프롬프트를 편집기로 수정하는 명령어입니다
+var editCmd = &cobra.Command{
+	Use:   "edit <도구> <카테고리>",
+	Short: "프롬프트를 편집기로 수정합니다",
+	Long: `저장된 프롬프트를 $VISUAL 또는 $EDITOR(없으면 vi)로 엽니다.
+편집기를 종료하면 수정한 내용이 --scope 범위(기본값: user)에 저장됩니다.
+범위에 프롬프트가 없으면 다른 범위나 공유(@shared) 프롬프트의 내용으로, 그것도 없으면 빈 파일로 시작합니다.
+내용을 모두 지우고 종료하면 저장하지 않습니다.
+
+예시:
+  aide edit claude review
+  EDITOR="code --wait" aide edit cursor backend`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+		category := args[1]
+
+		// 지원되는 도구인지 확인
+		if err := checkPromptTool(tool); err != nil {
+			return err
+		}
+
+		// 카테고리 이름 검증
+		if strings.TrimSpace(category) == "" {
+			return fmt.Errorf("카테고리 이름은 비어있을 수 없습니다")
+		}
+
+		// 저장소 초기화
+		store, err := openScope(editScope)
+		if err != nil {
+			return err
+		}
+
+		// 범위에 프롬프트가 없으면 다른 범위나 공유 프롬프트의 내용으로 시작
+		initial := ""
+		if store.PromptExists(tool, category) {
+			if initial, err = store.GetPrompt(tool, category); err != nil {
+				return err
+			}
+		} else if layers, err := openCurrentLayers(); err == nil {
+			initial, _, _ = layers.ResolvePrompt(tool, category)
+		}
+
+		edited, err := editInEditor(fmt.Sprintf("aide-%s-%s-*.md", tool, strings.ReplaceAll(category, "/", "-")), initial)
+		if err != nil {
+			return err
+		}
+
+		if edited == initial {
+			fmt.Printf("변경 사항이 없습니다: %s/%s\n", tool, category)
+			return nil
+		}
+		if strings.TrimSpace(edited) == "" {
+			fmt.Println("내용이 비어 있어 저장하지 않았습니다.")
+			return nil
+		}
+
+		if err := store.SavePrompt(tool, category, edited); err != nil {
+			return fmt.Errorf("프롬프트를 저장하는 중 오류가 발생했습니다: %w", err)
+		}
+		err = store.UpdateMeta(tool, category, func(meta *storage.PromptMeta) {
+			if meta.Author == "" {
+				meta.Author = currentAuthor()
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("프롬프트 메타데이터를 저장하는 중 오류가 발생했습니다: %w", err)
+		}
+
+		fmt.Printf("프롬프트가 저장되었습니다: %s/%s\n", tool, category)
+		return nil
+	},
+}
+
+// editInEditor는 내용을 임시 파일에 쓰고 편집기로 연 뒤, 편집기가 종료되면 수정된 내용을 반환합니다
+func editInEditor(pattern, content string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("임시 파일을 만들 수 없습니다: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", fmt.Errorf("임시 파일에 쓸 수 없습니다: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("임시 파일에 쓸 수 없습니다: %w", err)
+	}
+
+	// "code --wait"처럼 인자가 포함된 편집기 명령도 허용
+	editor := strings.Fields(editorCommand())
+	command := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		return "", fmt.Errorf("편집기를 실행할 수 없습니다 (%s): %w", editor[0], err)
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("편집한 내용을 읽을 수 없습니다: %w", err)
+	}
+	return string(edited), nil
+}
+
+// editorCommand는 $VISUAL, $EDITOR 순서로 편집기 명령을 찾습니다
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return defaultEditor
+}
+
+func init() {
+	addScopeFlag(editCmd, &editScope)
+	rootCmd.AddCommand(editCmd)
+}
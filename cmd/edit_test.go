@@ -0,0 +1,28 @@
+package cmd
+
+import "testing"
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		visual   string
+		editor   string
+		expected string
+	}{
+		{name: "VISUAL 우선", visual: "code --wait", editor: "nano", expected: "code --wait"},
+		{name: "EDITOR", editor: "nano", expected: "nano"},
+		{name: "공백뿐인 VISUAL은 무시", visual: "  ", editor: "nano", expected: "nano"},
+		{name: "기본 편집기", expected: defaultEditor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+
+			if editor := editorCommand(); editor != tt.expected {
+				t.Errorf("편집기가 일치하지 않습니다: 예상 %q, 실제 %q", tt.expected, editor)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hooneun/aide/internal/diff"
+	"github.com/hooneun/aide/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// historyDiff는 비교할 리비전 범위입니다 ("N" 또는 "N..M")
+var historyDiff string
+
+// historyScope는 프롬프트를 다룰 저장소 범위입니다
+var historyScope string
+
+// historyCmd는 프롬프트의 리비전 목록을 보여주는 명령어입니다
+var historyCmd = &cobra.Command{
+	Use:   "history <도구> <카테고리>",
+	Short: "프롬프트의 저장 이력을 보여줍니다",
+	Long: `프롬프트를 저장할 때마다 기록된 리비전을 나열합니다.
+현재 프롬프트와 같은 가장 최근 리비전에 *가 표시됩니다.
+
+--diff N은 N번 리비전과 현재 프롬프트를, --diff N..M은 두 리비전을 unified diff로 비교합니다.
+
+예시:
+  aide history claude review              # 리비전 목록
+  aide history claude review --diff 2     # 2번 리비전과 현재 프롬프트 비교
+  aide history claude review --diff 1..3  # 1번과 3번 리비전 비교`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+		category := args[1]
+
+		// 지원되는 도구인지 확인
+		if err := checkPromptTool(tool); err != nil {
+			return err
+		}
+
+		// 저장소 초기화
+		store, err := openScope(historyScope)
+		if err != nil {
+			return err
+		}
+
+		if historyDiff != "" {
+			return printRevisionDiff(store, tool, category, historyDiff)
+		}
+
+		revisions, err := store.ListRevisions(tool, category)
+		if err != nil {
+			return err
+		}
+		if len(revisions) == 0 {
+			fmt.Printf("%s/%s의 저장 이력이 없습니다.\n", tool, category)
+			return nil
+		}
+
+		current, _ := store.GetPrompt(tool, category)
+
+		fmt.Printf("=== %s/%s 저장 이력 ===\n", tool, category)
+		marked := false
+		for i := len(revisions) - 1; i >= 0; i-- {
+			revision := revisions[i]
+			content, err := store.GetRevision(tool, category, revision.Number)
+			if err != nil {
+				return err
+			}
+
+			mark := " "
+			if !marked && content == current {
+				mark, marked = "*", true
+			}
+			fmt.Printf("%s %3d  %s  %s\n", mark, revision.Number,
+				revision.Time.Local().Format("2006-01-02 15:04:05"), firstLine(content))
+		}
+		return nil
+	},
+}
+
+// printRevisionDiff는 리비전 범위("N" 또는 "N..M")의 차이를 unified diff로 출력합니다
+func printRevisionDiff(store *storage.Storage, tool, category, spec string) error {
+	from, to, ranged := strings.Cut(spec, "..")
+
+	fromRev, err := strconv.Atoi(from)
+	if err != nil {
+		return fmt.Errorf("리비전 번호가 올바르지 않습니다: %s", spec)
+	}
+	oldText, err := store.GetRevision(tool, category, fromRev)
+	if err != nil {
+		return err
+	}
+	oldName := fmt.Sprintf("%s/%s@%d", tool, category, fromRev)
+
+	var newText, newName string
+	if ranged {
+		toRev, err := strconv.Atoi(to)
+		if err != nil {
+			return fmt.Errorf("리비전 번호가 올바르지 않습니다: %s", spec)
+		}
+		if newText, err = store.GetRevision(tool, category, toRev); err != nil {
+			return err
+		}
+		newName = fmt.Sprintf("%s/%s@%d", tool, category, toRev)
+	} else {
+		if newText, err = store.GetPrompt(tool, category); err != nil {
+			return err
+		}
+		newName = fmt.Sprintf("%s/%s", tool, category)
+	}
+
+	out := diff.Unified(oldName, newName, oldText, newText)
+	if out == "" {
+		fmt.Println("두 리비전의 내용이 같습니다.")
+		return nil
+	}
+	fmt.Print(out)
+	return nil
+}
+
+// firstLine은 미리보기용으로 내용의 첫 줄을 반환합니다
+func firstLine(content string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	if runes := []rune(line); len(runes) > 60 {
+		line = string(runes[:60]) + "…"
+	}
+	return line
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyDiff, "diff", "", "비교할 리비전 (N: 현재와 비교, N..M: 두 리비전 비교)")
+	addScopeFlag(historyCmd, &historyScope)
+	rootCmd.AddCommand(historyCmd)
+}
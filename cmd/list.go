@@ -3,35 +3,42 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strings"
 
-	"github.com/hooneun/aide/internal/config"
 	"github.com/hooneun/aide/internal/storage"
 
 	"github.com/spf13/cobra"
 )
 
+// listOrigin은 각 프롬프트를 찾은 저장소 범위를 표시할지 여부입니다
+var listOrigin bool
+
+// listLong은 프롬프트 메타데이터를 함께 표시할지 여부입니다
+var listLong bool
+
 // listCmd는 저장된 프롬프트를 나열하는 명령어입니다
 var listCmd = &cobra.Command{
 	Use:   "list [도구]",
 	Short: "저장된 프롬프트를 나열합니다",
 	Long: `모든 프롬프트 또는 특정 도구의 프롬프트를 나열합니다.
+도구를 지정하면 그 도구에 적용할 수 있는 공유(@shared) 프롬프트도 함께 표시합니다.
+프로젝트, 팀, 사용자, 시스템 저장소의 프롬프트를 모두 보여주며, 같은 프롬프트가 여러 범위에 있으면
+우선순위가 가장 높은 범위의 것을 사용합니다. --origin을 지정하면 각 프롬프트를 찾은 범위를 표시합니다.
+--long을 지정하면 설명, 태그, globs, 작성자, 수정 시각도 함께 표시합니다.
 
 예시:
   aide list          # 모든 프롬프트 나열
   aide list claude   # Claude 프롬프트만 나열
-  aide list cursor   # Cursor 프롬프트만 나열`,
+  aide list cursor   # Cursor 프롬프트만 나열 (적용 가능한 공유 프롬프트 포함)
+  aide list @shared  # 공유 프롬프트만 나열
+  aide list --origin # 프롬프트마다 범위(project, team, user, system) 표시
+  aide list claude -l  # 메타데이터와 함께 표시`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// 설정 초기화
-		cfg, err := config.New()
+		// 현재 프로젝트 기준 계층화된 저장소
+		layers, err := openCurrentLayers()
 		if err != nil {
-			return fmt.Errorf("설정을 초기화할 수 없습니다: %w", err)
-		}
-
-		// 저장소 초기화
-		store, err := storage.New()
-		if err != nil {
-			return fmt.Errorf("저장소를 초기화할 수 없습니다: %w", err)
+			return err
 		}
 
 		// 특정 도구가 지정된 경우
@@ -39,32 +46,63 @@ var listCmd = &cobra.Command{
 			tool := args[0]
 
 			// 지원되는 도구인지 확인
-			if err := cfg.ValidateTool(tool); err != nil {
+			if err := checkPromptTool(tool); err != nil {
 				return err
 			}
 
-			categories, err := store.ListPrompts(tool)
+			origins, err := layers.ListPrompts(tool)
 			if err != nil {
 				return fmt.Errorf("프롬프트 목록을 가져오는 중 오류가 발생했습니다: %w", err)
 			}
 
-			if len(categories) == 0 {
+			if len(origins) == 0 {
 				fmt.Printf("%s 도구에 저장된 프롬프트가 없습니다.\n", tool)
 				return nil
 			}
 
+			// 공유 프롬프트를 재정의하는 도구 전용 프롬프트 표시
+			shared, err := layers.ListPrompts(storage.SharedTool)
+			if err != nil {
+				return fmt.Errorf("프롬프트 목록을 가져오는 중 오류가 발생했습니다: %w", err)
+			}
+
 			// 카테고리 정렬
+			categories := make([]string, 0, len(origins))
+			width := 0
+			for category := range origins {
+				categories = append(categories, category)
+				width = max(width, len(category))
+			}
 			sort.Strings(categories)
 
 			fmt.Printf("=== %s 프롬프트 목록 ===\n", tool)
 			for _, category := range categories {
-				fmt.Printf("  - %s\n", category)
+				origin := origins[category]
+
+				kind := "전용"
+				if origin.Shared() {
+					kind = "공유 (" + storage.SharedTool + ")"
+					if tool == storage.SharedTool {
+						kind = "공유"
+					}
+				} else if _, ok := shared[category]; ok {
+					kind = "전용 (" + storage.SharedTool + " 재정의)"
+				}
+				if listOrigin {
+					kind += " [" + origin.Scope + "]"
+				}
+				fmt.Printf("  - %-*s  %s\n", width, category, kind)
+				if listLong {
+					if err := printPromptMeta(layers, origin, category); err != nil {
+						return err
+					}
+				}
 			}
 			return nil
 		}
 
 		// 모든 도구의 프롬프트 나열
-		allPrompts, err := store.ListAllPrompts()
+		allPrompts, err := layers.ListAllPrompts()
 		if err != nil {
 			return fmt.Errorf("프롬프트 목록을 가져오는 중 오류가 발생했습니다: %w", err)
 		}
@@ -83,18 +121,36 @@ var listCmd = &cobra.Command{
 
 		fmt.Println("=== 저장된 프롬프트 목록 ===")
 		for _, tool := range tools {
-			categories := allPrompts[tool]
-			if len(categories) == 0 {
+			scopes := allPrompts[tool]
+			if len(scopes) == 0 {
 				continue
 			}
 
-			fmt.Printf("\n%s:\n", tool)
+			if tool == storage.SharedTool {
+				fmt.Printf("\n%s (공유, 모든 도구에 적용 가능):\n", tool)
+			} else {
+				fmt.Printf("\n%s:\n", tool)
+			}
 
 			// 카테고리 정렬
+			categories := make([]string, 0, len(scopes))
+			for category := range scopes {
+				categories = append(categories, category)
+			}
 			sort.Strings(categories)
 
 			for _, category := range categories {
-				fmt.Printf("  - %s\n", category)
+				if listOrigin {
+					fmt.Printf("  - %s [%s]\n", category, scopes[category])
+				} else {
+					fmt.Printf("  - %s\n", category)
+				}
+				if listLong {
+					origin := storage.Origin{Scope: scopes[category], Tool: tool}
+					if err := printPromptMeta(layers, origin, category); err != nil {
+						return err
+					}
+				}
 			}
 		}
 
@@ -102,6 +158,47 @@ var listCmd = &cobra.Command{
 	},
 }
 
+// printPromptMeta는 프롬프트 메타데이터를 목록 항목 아래에 출력합니다. 비어 있는 항목은 생략합니다.
+func printPromptMeta(layers *storage.Layers, origin storage.Origin, category string) error {
+	meta, err := layers.GetMeta(origin, category)
+	if err != nil {
+		return err
+	}
+
+	if meta.Description != "" {
+		fmt.Printf("      설명: %s\n", meta.Description)
+	}
+	if len(meta.Tags) > 0 {
+		fmt.Printf("      태그: %s\n", strings.Join(meta.Tags, ", "))
+	}
+	if len(meta.Globs) > 0 {
+		fmt.Printf("      globs: %s\n", strings.Join(meta.Globs, ", "))
+	}
+	if meta.Extends != "" {
+		fmt.Printf("      상속: %s\n", meta.Extends)
+	}
+	if meta.Raw {
+		fmt.Println("      템플릿으로 렌더링하지 않음 (--raw)")
+	}
+
+	var details []string
+	if meta.Author != "" {
+		details = append(details, "작성자: "+meta.Author)
+	}
+	if !meta.Created.IsZero() {
+		details = append(details, "생성: "+meta.Created.Local().Format("2006-01-02 15:04"))
+	}
+	if !meta.Updated.IsZero() {
+		details = append(details, "수정: "+meta.Updated.Local().Format("2006-01-02 15:04"))
+	}
+	if len(details) > 0 {
+		fmt.Printf("      %s\n", strings.Join(details, " · "))
+	}
+	return nil
+}
+
 func init() {
+	listCmd.Flags().BoolVar(&listOrigin, "origin", false, "프롬프트를 찾은 저장소 범위 표시")
+	listCmd.Flags().BoolVarP(&listLong, "long", "l", false, "설명, 태그, 작성자, 수정 시각 등 메타데이터 표시")
 	rootCmd.AddCommand(listCmd)
 }
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hooneun/aide/internal/generators"
+	"github.com/hooneun/aide/internal/registry"
+
+	"github.com/spf13/cobra"
+)
+
+// migrateCursorDryRun은 파일을 쓰지 않고 변경 내용만 보여줄지 여부입니다
+var migrateCursorDryRun bool
+
+// migrateCursorCmd는 .cursorrules의 aide 영역을 .cursor/rules/*.mdc 파일로 옮기는 명령어입니다
+var migrateCursorCmd = &cobra.Command{
+	Use:   "migrate-cursor",
+	Short: ".cursorrules의 aide 프롬프트를 .cursor/rules/*.mdc 파일로 옮깁니다",
+	Long: `현재 프로젝트의 .cursorrules에서 aide가 관리하는 카테고리 영역을 찾아
+카테고리마다 .cursor/rules/<카테고리>.mdc 규칙 파일로 옮깁니다.
+규칙 파일은 'aide apply'와 같이 저장소의 프롬프트와 메타데이터(설명, globs)로 만들며,
+저장소에서 지운 프롬프트는 .cursorrules의 영역 내용을 그대로 옮깁니다.
+영역 바깥에 직접 작성한 내용은 .cursorrules에 그대로 남고, 남은 내용이 없으면 파일을 삭제합니다.
+
+규칙 디렉터리에 전환 기록(.cursor/rules/.aide-rules)을 남기며, 이 파일이 있는 프로젝트에서만
+'aide apply cursor'가 .mdc 규칙 파일에 적용합니다. 옮길 영역이 없어도 전환 기록을 만드므로
+새 프로젝트에서 .mdc 규칙 파일을 쓰려면 이 명령을 한 번 실행하세요.
+
+예시:
+  aide migrate-cursor                         # .cursorrules를 규칙 파일로 분리하고 전환 기록
+  aide migrate-cursor --dry-run               # 옮기지 않고 변경 내용만 확인`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("현재 디렉터리를 가져올 수 없습니다: %w", err)
+		}
+		rulesFile := filepath.Join(currentDir, ".cursorrules")
+		rulesDir := filepath.Join(currentDir, registry.CursorRulesDir)
+
+		// apply와 같이 저장소에서 프롬프트와 메타데이터를 찾아 규칙 파일을 만듦
+		layers, err := openCurrentLayers()
+		if err != nil {
+			return err
+		}
+		vars, err := currentTemplateVars()
+		if err != nil {
+			return err
+		}
+		resolve := func(category string) (generators.Section, error) {
+			return resolveSection(layers, vars, "cursor", category)
+		}
+
+		changes, categories, err := generators.MigrateCursorRules(rulesFile, rulesDir, resolve)
+		if err != nil {
+			return fmt.Errorf("규칙을 옮기는 중 오류가 발생했습니다: %w", err)
+		}
+
+		if len(changes) == 0 {
+			fmt.Printf("이미 %s 규칙 파일을 사용하고 있습니다.\n", displayPath(rulesDir))
+			return nil
+		}
+
+		if migrateCursorDryRun {
+			printChanges(changes)
+			return exitWithCode(cmd, exitChangesPending)
+		}
+
+		if err := generators.WriteChanges(changes); err != nil {
+			return fmt.Errorf("규칙을 옮기는 중 오류가 발생했습니다: %w", err)
+		}
+
+		if len(categories) == 0 {
+			fmt.Printf("%s에 aide가 관리하는 영역이 없습니다.\n", displayPath(rulesFile))
+		} else {
+			fmt.Printf("%s의 프롬프트를 %s로 옮겼습니다.\n", displayPath(rulesFile), displayPath(rulesDir))
+			fmt.Printf("옮긴 카테고리: %s\n", strings.Join(categories, ", "))
+			if _, err := os.Stat(rulesFile); err == nil {
+				fmt.Printf("%s에는 직접 작성한 내용만 남았습니다.\n", displayPath(rulesFile))
+			}
+		}
+		fmt.Printf("이제 'aide apply cursor'는 %s의 .mdc 규칙 파일에 적용합니다.\n", displayPath(rulesDir))
+
+		return nil
+	},
+}
+
+func init() {
+	migrateCursorCmd.Flags().BoolVar(&migrateCursorDryRun, "dry-run", false, "파일을 쓰지 않고 변경 내용을 diff로 출력")
+	rootCmd.AddCommand(migrateCursorCmd)
+}
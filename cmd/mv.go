@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hooneun/aide/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// mvForce는 대상 프롬프트를 확인 없이 덮어쓸지 여부입니다
+var mvForce bool
+
+// mvScope는 프롬프트를 다룰 저장소 범위입니다
+var mvScope string
+
+// mvCmd는 프롬프트의 카테고리 이름을 바꾸는 명령어입니다
+var mvCmd = &cobra.Command{
+	Use:   "mv <도구> <카테고리> <새 카테고리>",
+	Short: "프롬프트의 카테고리 이름을 바꿉니다",
+	Long: `프롬프트의 카테고리 이름을 바꿉니다. 리비전 기록도 함께 옮겨집니다.
+새 카테고리에 프롬프트가 이미 있으면 덮어쓰기 전에 확인을 묻습니다.
+프로젝트에 적용된 영역은 이전 이름으로 남으므로, 필요하면 다시 적용하세요.
+
+예시:
+  aide mv claude review code-review
+  aide mv claude review code-review --force   # 확인 없이 덮어쓰기`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+		from := args[1]
+		to := args[2]
+
+		// 지원되는 도구인지 확인
+		if err := checkPromptTool(tool); err != nil {
+			return err
+		}
+
+		// 카테고리 이름 검증
+		if strings.TrimSpace(to) == "" {
+			return fmt.Errorf("카테고리 이름은 비어있을 수 없습니다")
+		}
+
+		// 저장소 초기화
+		store, err := openScope(mvScope)
+		if err != nil {
+			return err
+		}
+
+		err = store.RenamePrompt(tool, from, to, mvForce)
+		if errors.Is(err, storage.ErrPromptExists) {
+			if !confirm(fmt.Sprintf("%s/%s에 프롬프트가 이미 있습니다. 덮어쓸까요?", tool, to)) {
+				fmt.Println("취소되었습니다.")
+				return nil
+			}
+			err = store.RenamePrompt(tool, from, to, true)
+		}
+		if err != nil {
+			return fmt.Errorf("프롬프트 이름을 바꾸는 중 오류가 발생했습니다: %w", err)
+		}
+
+		fmt.Printf("프롬프트 이름이 바뀌었습니다: %s/%s -> %s/%s\n", tool, from, tool, to)
+		return nil
+	},
+}
+
+func init() {
+	mvCmd.Flags().BoolVarP(&mvForce, "force", "f", false, "대상 프롬프트를 확인 없이 덮어쓰기")
+	addScopeFlag(mvCmd, &mvScope)
+	rootCmd.AddCommand(mvCmd)
+}
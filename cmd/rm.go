@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// rmForce는 확인 없이 삭제할지 여부입니다
+var rmForce bool
+
+// rmScope는 프롬프트를 다룰 저장소 범위입니다
+var rmScope string
+
+// rmCmd는 저장된 프롬프트를 삭제하는 명령어입니다
+var rmCmd = &cobra.Command{
+	Use:   "rm <도구> <카테고리>[,카테고리2,...]",
+	Short: "저장된 프롬프트를 삭제합니다",
+	Long: `저장된 프롬프트를 삭제합니다. 삭제하기 전에 확인을 묻습니다.
+리비전 기록은 남으므로 'aide rollback'으로 되살릴 수 있습니다.
+프로젝트에 이미 적용된 내용은 바뀌지 않으므로, 필요하면 'aide unapply'를 먼저 실행하세요.
+
+예시:
+  aide rm claude review               # 확인 후 삭제
+  aide rm cursor backend,frontend -f  # 확인 없이 삭제`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+		categories := splitCategories(args[1])
+
+		// 지원되는 도구인지 확인
+		if err := checkPromptTool(tool); err != nil {
+			return err
+		}
+
+		// 저장소 초기화
+		store, err := openScope(rmScope)
+		if err != nil {
+			return err
+		}
+
+		if len(categories) == 0 {
+			return fmt.Errorf("삭제할 프롬프트가 없습니다")
+		}
+		for _, category := range categories {
+			if !store.PromptExists(tool, category) {
+				return fmt.Errorf("프롬프트를 찾을 수 없습니다: %s/%s", tool, category)
+			}
+		}
+
+		if !rmForce {
+			for _, category := range categories {
+				fmt.Printf("  - %s/%s\n", tool, category)
+			}
+			if !confirm(fmt.Sprintf("프롬프트 %d개를 삭제할까요?", len(categories))) {
+				fmt.Println("취소되었습니다.")
+				return nil
+			}
+		}
+
+		for _, category := range categories {
+			if err := store.DeletePrompt(tool, category); err != nil {
+				return fmt.Errorf("프롬프트를 삭제하는 중 오류가 발생했습니다: %w", err)
+			}
+			fmt.Printf("프롬프트가 삭제되었습니다: %s/%s\n", tool, category)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rmCmd.Flags().BoolVarP(&rmForce, "force", "f", false, "확인 없이 삭제")
+	addScopeFlag(rmCmd, &rmScope)
+	rootCmd.AddCommand(rmCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// rollbackScope는 프롬프트를 다룰 저장소 범위입니다
+var rollbackScope string
+
+// rollbackCmd는 프롬프트를 이전 리비전으로 되돌리는 명령어입니다
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <도구> <카테고리> <리비전>",
+	Short: "프롬프트를 이전 리비전으로 되돌립니다",
+	Long: `프롬프트를 'aide history'에 나오는 리비전의 내용으로 되돌립니다.
+되돌린 내용은 새 리비전으로 기록되므로, 되돌리기 전의 프롬프트도 이력에 남습니다.
+프로젝트에 반영하려면 'aide apply'를 다시 실행하세요.
+
+예시:
+  aide rollback claude review 2`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+		category := args[1]
+
+		revision, err := strconv.Atoi(args[2])
+		if err != nil || revision < 1 {
+			return fmt.Errorf("리비전 번호가 올바르지 않습니다: %s", args[2])
+		}
+
+		// 지원되는 도구인지 확인
+		if err := checkPromptTool(tool); err != nil {
+			return err
+		}
+
+		// 저장소 초기화
+		store, err := openScope(rollbackScope)
+		if err != nil {
+			return err
+		}
+
+		if err := store.RollbackPrompt(tool, category, revision); err != nil {
+			return fmt.Errorf("프롬프트를 되돌리는 중 오류가 발생했습니다: %w", err)
+		}
+
+		fmt.Printf("%s/%s를 %d번 리비전으로 되돌렸습니다.\n", tool, category, revision)
+		return nil
+	},
+}
+
+func init() {
+	addScopeFlag(rollbackCmd, &rollbackScope)
+	rootCmd.AddCommand(rollbackCmd)
+}
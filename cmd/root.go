@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+
+	"github.com/hooneun/aide/internal/storage"
 
 	"github.com/spf13/cobra"
 )
@@ -21,9 +26,54 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+// storeDir는 --store로 지정한 사용자 저장소 위치입니다 (비어 있으면 $AIDE_HOME 또는 ~/.aide)
+var storeDir string
+
+const (
+	// exitChangesPending은 적용되지 않은 변경 사항이 있을 때의 종료 코드입니다
+	exitChangesPending = 2
+	// exitDriftDetected는 aide verify가 적용 상태와 저장소(또는 매니페스트)의 차이를 찾았을 때의 종료 코드입니다
+	exitDriftDetected = 3
+)
+
+// exitCodeError는 오류 메시지 없이 특정 종료 코드로 끝내야 할 때 사용합니다
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("종료 코드 %d", e.code)
+}
+
+// exitWithCode는 cobra의 오류/사용법 출력을 끄고 종료 코드 오류를 반환합니다
+func exitWithCode(cmd *cobra.Command, code int) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &exitCodeError{code: code}
+}
+
+// confirm은 질문을 출력하고 사용자가 y로 답했는지 확인합니다. 입력이 없으면 거절로 봅니다.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&storeDir, "store", "", "사용자 저장소 위치 (기본값: $"+storage.HomeEnv+" 또는 ~/.aide)")
+}
+
 // Execute는 모든 하위 명령어를 root 명령어에 추가하고 플래그를 적절히 설정합니다
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		fmt.Println(err)
 		os.Exit(1)
 	}
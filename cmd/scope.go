@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hooneun/aide/internal/manifest"
+	"github.com/hooneun/aide/internal/registry"
+	"github.com/hooneun/aide/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// openUserStore는 --store, $AIDE_HOME, ~/.aide 순서로 정한 사용자 저장소를 엽니다.
+// 모든 명령어는 이 함수를 통해 사용자 저장소를 엽니다.
+func openUserStore() (*storage.Storage, error) {
+	store, err := storage.New(storeDir)
+	if err != nil {
+		return nil, fmt.Errorf("저장소를 초기화할 수 없습니다: %w", err)
+	}
+	return store, nil
+}
+
+// loadRegistry는 사용자 저장소의 도구 설정으로 도구 레지스트리를 만듭니다
+func loadRegistry() (*registry.Registry, error) {
+	store, err := openUserStore()
+	if err != nil {
+		return nil, err
+	}
+	reg, err := registry.New(store)
+	if err != nil {
+		return nil, fmt.Errorf("도구 목록을 불러올 수 없습니다: %w", err)
+	}
+	return reg, nil
+}
+
+// openLayers는 dir 기준으로 찾은 프로젝트 저장소를 포함하여 계층화된 저장소를 엽니다
+func openLayers(dir string) (*storage.Layers, error) {
+	user, err := openUserStore()
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewLayers(user, storage.FindProjectDir(dir, user.Dir())), nil
+}
+
+// openCurrentLayers는 현재 디렉터리 기준으로 계층화된 저장소를 엽니다
+func openCurrentLayers() (*storage.Layers, error) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("현재 디렉터리를 가져올 수 없습니다: %w", err)
+	}
+	return openLayers(currentDir)
+}
+
+// openScope는 프롬프트를 쓸 범위의 저장소를 엽니다.
+// 프로젝트 저장소가 아직 없으면 매니페스트가 있는 디렉터리(없으면 현재 디렉터리)에 새로 만듭니다.
+func openScope(scope string) (*storage.Storage, error) {
+	user, err := openUserStore()
+	if err != nil {
+		return nil, err
+	}
+	if scope == "" || scope == storage.ScopeUser {
+		return user, nil
+	}
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("현재 디렉터리를 가져올 수 없습니다: %w", err)
+	}
+
+	projectDir := storage.FindProjectDir(currentDir, user.Dir())
+	if projectDir == "" && scope == storage.ScopeProject {
+		projectDir = currentDir
+		if path, err := manifest.Find(currentDir); err == nil {
+			projectDir = filepath.Dir(path)
+		}
+		// 사용자 저장소 자리(~/.aide)에는 프로젝트 저장소를 만들지 않음
+		if homeDir, err := os.UserHomeDir(); err == nil && projectDir == homeDir {
+			return nil, fmt.Errorf("홈 디렉터리에는 프로젝트 저장소를 만들 수 없습니다 (%s는 기본 사용자 저장소입니다)", filepath.Join(homeDir, storage.StoreDirName))
+		}
+	}
+
+	return storage.NewLayers(user, projectDir).Store(scope)
+}
+
+// addScopeFlag는 프롬프트를 쓸 저장소 범위를 지정하는 --scope 플래그를 추가합니다
+func addScopeFlag(cmd *cobra.Command, scope *string) {
+	cmd.Flags().StringVar(scope, "scope", storage.ScopeUser, "저장소 범위 (project, team, user, system)")
+}
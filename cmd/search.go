@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/hooneun/aide/internal/query"
+	"github.com/hooneun/aide/internal/search"
+	"github.com/hooneun/aide/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// searchRegex는 검색어를 정규식으로 해석할지 여부입니다
+	searchRegex bool
+	// searchCaseSensitive는 대소문자를 구분할지 여부입니다
+	searchCaseSensitive bool
+	// searchTool은 검색할 도구입니다 (비어 있으면 모든 도구와 공유 프롬프트)
+	searchTool string
+	// searchTags는 검색할 프롬프트를 고를 태그 목록입니다 (하나라도 있으면 검색)
+	searchTags []string
+	// searchContext는 일치한 줄 앞뒤로 보여줄 줄 수입니다
+	searchContext int
+)
+
+// 일치 구간 강조에 사용할 터미널 색상
+const (
+	colorMatch  = "\033[1;31m"
+	colorHeader = "\033[1m"
+	colorReset  = "\033[0m"
+)
+
+// searchCmd는 저장된 프롬프트 내용을 검색하는 명령어입니다
+var searchCmd = &cobra.Command{
+	Use:   "search <검색어>",
+	Short: "저장된 프롬프트 내용을 검색합니다",
+	Long: `모든 도구와 공유(@shared) 프롬프트의 내용에서 검색어를 찾습니다.
+프로젝트, 팀, 사용자, 시스템 저장소를 모두 검색하며, 같은 프롬프트가 여러 범위에 있으면
+우선순위가 가장 높은 범위의 것을 검색합니다.
+
+기본적으로 대소문자를 구분하지 않고 검색어를 문자열 그대로 찾습니다.
+--regex를 지정하면 검색어를 정규식(Go RE2 문법)으로 해석합니다.
+일치한 줄은 앞뒤 문맥과 함께 줄 번호를 붙여 출력하며, 터미널에서는 일치한 부분을 강조합니다.
+(NO_COLOR 환경 변수를 설정하면 강조하지 않습니다.)
+
+--tool을 지정하면 그 도구에 적용되는 프롬프트(공유 프롬프트 포함)만 검색하고,
+--tag를 지정하면 태그가 하나라도 있는 프롬프트만 검색합니다.
+
+예시:
+  aide search "error wrapping"               # 모든 프롬프트에서 검색
+  aide search -E 'fmt\.Errorf|errors\.Is'    # 정규식 검색
+  aide search 보안 --tool claude             # Claude에 적용되는 프롬프트만 검색
+  aide search 테스트 --tag go -C 0           # go 태그 프롬프트에서 문맥 없이 검색`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		re, err := search.Compile(args[0], searchRegex, searchCaseSensitive)
+		if err != nil {
+			return err
+		}
+		if searchContext < 0 {
+			return fmt.Errorf("문맥 줄 수는 0 이상이어야 합니다: %d", searchContext)
+		}
+
+		var tags query.Expr
+		if len(storage.NormalizeTags(searchTags)) > 0 {
+			tags = query.AnyTag(searchTags)
+		}
+
+		// 현재 프로젝트 기준 계층화된 저장소
+		layers, err := openCurrentLayers()
+		if err != nil {
+			return err
+		}
+
+		targets, err := searchTargets(layers, searchTool)
+		if err != nil {
+			return err
+		}
+
+		color := useColor()
+		matchedPrompts, matchedLines := 0, 0
+		for _, target := range targets {
+			if tags != nil {
+				meta, err := layers.GetMeta(target.origin, target.category)
+				if err != nil {
+					return err
+				}
+				if !tags.Match(target.category, meta) {
+					continue
+				}
+			}
+
+			store, err := layers.Store(target.origin.Scope)
+			if err != nil {
+				return err
+			}
+			prompt, err := store.GetPrompt(target.origin.Tool, target.category)
+			if err != nil {
+				return err
+			}
+
+			hunks := search.Find(prompt, re, searchContext)
+			if len(hunks) == 0 {
+				continue
+			}
+
+			if matchedPrompts > 0 {
+				fmt.Println()
+			}
+			printSearchResult(target, hunks, color)
+			matchedPrompts++
+			matchedLines += search.Count(hunks)
+		}
+
+		if matchedPrompts == 0 {
+			fmt.Println("일치하는 프롬프트가 없습니다.")
+			return nil
+		}
+		fmt.Printf("\n%d개 프롬프트에서 %d줄이 일치합니다.\n", matchedPrompts, matchedLines)
+		return nil
+	},
+}
+
+// searchTarget은 검색할 프롬프트 하나입니다
+type searchTarget struct {
+	origin   storage.Origin
+	category string
+}
+
+// searchTargets는 검색할 프롬프트를 도구, 카테고리 순서로 반환합니다.
+// tool이 있으면 그 도구에 적용되는 프롬프트(공유 프롬프트 포함)만 반환합니다.
+func searchTargets(layers *storage.Layers, tool string) ([]searchTarget, error) {
+	var targets []searchTarget
+	if tool != "" {
+		// 지원되는 도구인지 확인
+		if err := checkPromptTool(tool); err != nil {
+			return nil, err
+		}
+
+		origins, err := layers.ListPrompts(tool)
+		if err != nil {
+			return nil, fmt.Errorf("프롬프트 목록을 가져오는 중 오류가 발생했습니다: %w", err)
+		}
+		for category, origin := range origins {
+			targets = append(targets, searchTarget{origin, category})
+		}
+	} else {
+		all, err := layers.ListAllPrompts()
+		if err != nil {
+			return nil, fmt.Errorf("프롬프트 목록을 가져오는 중 오류가 발생했습니다: %w", err)
+		}
+		for tool, scopes := range all {
+			for category, scope := range scopes {
+				targets = append(targets, searchTarget{storage.Origin{Scope: scope, Tool: tool}, category})
+			}
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].origin.Tool != targets[j].origin.Tool {
+			return targets[i].origin.Tool < targets[j].origin.Tool
+		}
+		return targets[i].category < targets[j].category
+	})
+	return targets, nil
+}
+
+// printSearchResult는 프롬프트 하나의 검색 결과를 grep과 비슷한 형식으로 출력합니다.
+// 일치한 줄은 번호 뒤에 ':'를, 문맥 줄은 '-'를 붙이고 떨어진 묶음 사이에는 '--'를 출력합니다.
+func printSearchResult(target searchTarget, hunks []search.Hunk, color bool) {
+	header := fmt.Sprintf("%s/%s [%s]", target.origin.Tool, target.category, target.origin.Scope)
+	if color {
+		header = colorHeader + header + colorReset
+	}
+	fmt.Println(header)
+
+	// 줄 번호 너비는 마지막 줄 번호 기준
+	last := hunks[len(hunks)-1]
+	width := len(strconv.Itoa(last[len(last)-1].Number))
+	for i, hunk := range hunks {
+		if i > 0 {
+			fmt.Println("  --")
+		}
+		for _, line := range hunk {
+			sep, text := "-", line.Text
+			if line.IsMatch() {
+				sep = ":"
+				if color {
+					text = search.Highlight(line, colorMatch, colorReset)
+				}
+			}
+			fmt.Printf("  %*d%s %s\n", width, line.Number, sep, text)
+		}
+	}
+}
+
+// useColor는 표준 출력이 터미널이고 NO_COLOR가 설정되지 않았는지 확인합니다
+func useColor() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	searchCmd.Flags().BoolVarP(&searchRegex, "regex", "E", false, "검색어를 정규식으로 해석")
+	searchCmd.Flags().BoolVarP(&searchCaseSensitive, "case-sensitive", "s", false, "대소문자 구분")
+	searchCmd.Flags().StringVar(&searchTool, "tool", "", "검색할 도구 (공유 프롬프트 포함)")
+	searchCmd.Flags().StringSliceVar(&searchTags, "tag", nil, "태그가 하나라도 있는 프롬프트만 검색 (쉼표로 구분)")
+	searchCmd.Flags().IntVarP(&searchContext, "context", "C", 1, "일치한 줄 앞뒤로 보여줄 줄 수")
+	rootCmd.AddCommand(searchCmd)
+}
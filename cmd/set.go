@@ -2,44 +2,86 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"os/user"
 	"strings"
 
-	"github.com/hooneun/aide/internal/config"
 	"github.com/hooneun/aide/internal/storage"
 
 	"github.com/spf13/cobra"
 )
 
+// setFile은 프롬프트를 읽어올 파일 경로입니다
+var setFile string
+
+// setScope는 프롬프트를 저장할 저장소 범위입니다
+var setScope string
+
+// 프롬프트 메타데이터 설정
+var (
+	setDescription string   // 설명
+	setTags        []string // 태그 (지정하면 기존 태그를 교체)
+	setGlobs       []string // 적용할 파일 패턴
+	setRaw         bool     // 템플릿으로 렌더링하지 않고 그대로 적용
+	setExtends     string   // 상속할 부모 프롬프트 (<도구>/<카테고리>)
+)
+
+// authorEnv는 프롬프트 작성자 이름을 지정하는 환경 변수입니다
+const authorEnv = "AIDE_AUTHOR"
+
 // setCmd는 프롬프트를 저장하는 명령어입니다
 var setCmd = &cobra.Command{
-	Use:   "set <도구> <카테고리> <프롬프트>",
+	Use:   "set <도구> <카테고리> [프롬프트|-]",
 	Short: "프롬프트를 저장합니다",
 	Long: `특정 도구와 카테고리에 프롬프트를 저장합니다.
+프롬프트 대신 --file로 파일 경로를 지정하거나, '-'를 지정하여 표준 입력에서 읽을 수 있습니다.
+--scope로 저장할 저장소 범위(project, team, user, system)를 지정합니다 (기본값: user).
+
+--desc, --tag, --glob으로 프롬프트 메타데이터를 함께 저장합니다. 프롬프트 없이 메타데이터 플래그만
+지정하면 저장된 프롬프트의 메타데이터만 수정합니다. 작성자는 $AIDE_AUTHOR(없으면 사용자 계정 이름)로 기록됩니다.
+
+프롬프트는 적용할 때 Go text/template으로 렌더링됩니다 ('aide apply --help' 참고).
+{{ }}가 템플릿이 아닌 프롬프트(예: Vue, Handlebars 예제)는 --raw로 그대로 적용하게 할 수 있습니다.
+
+프롬프트 안의 {{include "<도구>/<카테고리>"}}는 적용할 때 해당 프롬프트 내용으로 바뀌며,
+--extends로 부모 프롬프트를 지정하면 부모 프롬프트 내용 뒤에 이 프롬프트가 이어집니다.
+(shared/<카테고리>는 @shared/<카테고리>와 같습니다. 펼친 내용은 'aide show --resolved'로 확인하세요.)
 
 예시:
   aide set claude review "보안 취약점과 성능 문제를 체크해줘"
-  aide set cursor backend "Go 모범 사례와 에러 핸들링에 집중해줘"`,
-	Args: cobra.ExactArgs(3),
+  aide set cursor backend "Go 모범 사례와 에러 핸들링에 집중해줘"
+  aide set claude backend --file prompts/backend.md
+  cat prompts/backend.md | aide set claude backend -
+  aide set claude review "팀 리뷰 규칙" --scope project   # 저장소의 .aide/에 저장
+  aide set claude backend --desc "Go 백엔드 규칙" --tag go,backend   # 메타데이터만 수정
+  aide set cursor vue --file prompts/vue.md --raw                    # 템플릿으로 렌더링하지 않음
+  aide set claude review "리뷰 규칙" --extends shared/base          # 공통 프롬프트 상속`,
+	Args: cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		tool := args[0]
 		category := args[1]
-		prompt := args[2]
 
-		// 설정 초기화
-		cfg, err := config.New()
-		if err != nil {
-			return fmt.Errorf("설정을 초기화할 수 없습니다: %w", err)
+		// 프롬프트 없이 메타데이터 플래그만 지정하면 메타데이터만 수정
+		metaOnly := len(args) == 2 && setFile == "" && metaFlagsChanged(cmd)
+
+		var prompt string
+		if !metaOnly {
+			var err error
+			if prompt, err = readPromptInput(args[2:], setFile, os.Stdin); err != nil {
+				return err
+			}
 		}
 
 		// 지원되는 도구인지 확인
-		if err := cfg.ValidateTool(tool); err != nil {
+		if err := checkPromptTool(tool); err != nil {
 			return err
 		}
 
 		// 저장소 초기화
-		store, err := storage.New()
+		store, err := openScope(setScope)
 		if err != nil {
-			return fmt.Errorf("저장소를 초기화할 수 없습니다: %w", err)
+			return err
 		}
 
 		// 카테고리 이름 검증
@@ -47,21 +89,112 @@ var setCmd = &cobra.Command{
 			return fmt.Errorf("카테고리 이름은 비어있을 수 없습니다")
 		}
 
-		// 프롬프트 검증
-		if strings.TrimSpace(prompt) == "" {
-			return fmt.Errorf("프롬프트는 비어있을 수 없습니다")
+		// 부모 프롬프트 참조 검증 (빈 값이면 상속 해제)
+		if setExtends != "" {
+			if _, _, err := storage.ParseRef(setExtends); err != nil {
+				return err
+			}
 		}
 
-		// 프롬프트 저장
-		if err := store.SavePrompt(tool, category, prompt); err != nil {
-			return fmt.Errorf("프롬프트를 저장하는 중 오류가 발생했습니다: %w", err)
+		if !metaOnly {
+			// 프롬프트 저장
+			if err := store.SavePrompt(tool, category, prompt); err != nil {
+				return fmt.Errorf("프롬프트를 저장하는 중 오류가 발생했습니다: %w", err)
+			}
+		}
+
+		// 메타데이터 저장
+		err = store.UpdateMeta(tool, category, func(meta *storage.PromptMeta) {
+			if cmd.Flags().Changed("desc") {
+				meta.Description = strings.TrimSpace(setDescription)
+			}
+			if cmd.Flags().Changed("tag") {
+				meta.Tags = setTags
+			}
+			if cmd.Flags().Changed("glob") {
+				meta.Globs = setGlobs
+			}
+			if cmd.Flags().Changed("raw") {
+				meta.Raw = setRaw
+			}
+			if cmd.Flags().Changed("extends") {
+				meta.Extends = strings.TrimSpace(setExtends)
+			}
+			if meta.Author == "" {
+				meta.Author = currentAuthor()
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("프롬프트 메타데이터를 저장하는 중 오류가 발생했습니다: %w", err)
 		}
 
-		fmt.Printf("프롬프트가 저장되었습니다: %s/%s\n", tool, category)
+		if metaOnly {
+			fmt.Printf("메타데이터가 저장되었습니다: %s/%s (%s)\n", tool, category, setScope)
+		} else {
+			fmt.Printf("프롬프트가 저장되었습니다: %s/%s (%s)\n", tool, category, setScope)
+		}
 		return nil
 	},
 }
 
+// metaFlagsChanged는 메타데이터 플래그가 하나라도 지정되었는지 확인합니다
+func metaFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{"desc", "tag", "glob", "raw", "extends"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// currentAuthor는 메타데이터에 기록할 작성자 이름을 반환합니다
+func currentAuthor() string {
+	if author := strings.TrimSpace(os.Getenv(authorEnv)); author != "" {
+		return author
+	}
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+	return ""
+}
+
+// readPromptInput은 인자, 파일 또는 표준 입력('-')에서 프롬프트를 읽습니다. 빈 프롬프트는 오류입니다.
+func readPromptInput(args []string, file string, stdin io.Reader) (string, error) {
+	var prompt string
+	switch {
+	case file != "" && len(args) > 0:
+		return "", fmt.Errorf("프롬프트 인자와 --file은 함께 사용할 수 없습니다")
+	case file != "":
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("프롬프트 파일을 읽을 수 없습니다: %w", err)
+		}
+		prompt = string(content)
+	case len(args) == 0:
+		return "", fmt.Errorf("프롬프트를 인자, --file 또는 '-'(표준 입력)로 지정하세요")
+	case args[0] == "-":
+		content, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("표준 입력을 읽을 수 없습니다: %w", err)
+		}
+		prompt = string(content)
+	default:
+		prompt = args[0]
+	}
+
+	if strings.TrimSpace(prompt) == "" {
+		return "", fmt.Errorf("프롬프트는 비어있을 수 없습니다")
+	}
+	return prompt, nil
+}
+
 func init() {
+	setCmd.Flags().StringVar(&setFile, "file", "", "프롬프트를 읽어올 파일 경로")
+	setCmd.Flags().StringVar(&setDescription, "desc", "", "프롬프트 설명")
+	setCmd.Flags().StringSliceVar(&setTags, "tag", nil, "프롬프트 태그 (쉼표로 구분, 기존 태그를 교체)")
+	setCmd.Flags().StringSliceVar(&setGlobs, "glob", nil, "프롬프트를 적용할 파일 패턴 (쉼표로 구분, 예: Cursor 규칙의 globs)")
+	setCmd.Flags().BoolVar(&setRaw, "raw", false, "템플릿으로 렌더링하지 않고 그대로 적용 (--raw=false로 해제)")
+	setCmd.Flags().StringVar(&setExtends, "extends", "", "상속할 부모 프롬프트 (<도구>/<카테고리>, 빈 값이면 해제)")
+	addScopeFlag(setCmd, &setScope)
 	rootCmd.AddCommand(setCmd)
 }
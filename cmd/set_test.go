@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadPromptInput(t *testing.T) {
+	dir := t.TempDir()
+	promptFile := filepath.Join(dir, "prompt.md")
+	if err := os.WriteFile(promptFile, []byte("# 백엔드 규칙\n\n에러는 감싸서 반환해줘\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty.md")
+	if err := os.WriteFile(emptyFile, []byte(" \n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		file     string
+		stdin    string
+		expected string
+		errPart  string // 기대하는 오류 메시지 일부 (비어 있으면 성공)
+	}{
+		{
+			name:     "인자",
+			args:     []string{"간결하게 답해줘"},
+			expected: "간결하게 답해줘",
+		},
+		{
+			name:     "--file",
+			file:     promptFile,
+			expected: "# 백엔드 규칙\n\n에러는 감싸서 반환해줘\n",
+		},
+		{
+			name:     "표준 입력",
+			args:     []string{"-"},
+			stdin:    "여러 줄\n프롬프트\n",
+			expected: "여러 줄\n프롬프트\n",
+		},
+		{
+			name:    "인자와 --file 함께 사용",
+			args:    []string{"간결하게"},
+			file:    promptFile,
+			errPart: "함께 사용할 수 없습니다",
+		},
+		{
+			name:    "프롬프트 없음",
+			errPart: "지정하세요",
+		},
+		{
+			name:    "없는 파일",
+			file:    filepath.Join(dir, "missing.md"),
+			errPart: "프롬프트 파일을 읽을 수 없습니다",
+		},
+		{
+			name:    "빈 파일",
+			file:    emptyFile,
+			errPart: "비어있을 수 없습니다",
+		},
+		{
+			name:    "빈 표준 입력",
+			args:    []string{"-"},
+			errPart: "비어있을 수 없습니다",
+		},
+		{
+			name:    "공백뿐인 인자",
+			args:    []string{"  "},
+			errPart: "비어있을 수 없습니다",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt, err := readPromptInput(tt.args, tt.file, strings.NewReader(tt.stdin))
+			if tt.errPart != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+					t.Errorf("%q를 포함한 오류를 기대했습니다: %v", tt.errPart, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("프롬프트 읽기 실패: %v", err)
+			}
+			if prompt != tt.expected {
+				t.Errorf("프롬프트가 일치하지 않습니다.\n예상: %q\n실제: %q", tt.expected, prompt)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// showRevision은 출력할 리비전 번호입니다 (0이면 현재 프롬프트)
+var showRevision int
+
+// showScope는 프롬프트를 읽을 저장소 범위입니다 (비어 있으면 모든 범위에서 찾음)
+var showScope string
+
+// showResolved는 포함, 상속, 템플릿을 모두 펼친 내용을 출력할지 여부입니다
+var showResolved bool
+
+// showCmd는 저장된 프롬프트 내용을 출력하는 명령어입니다
+var showCmd = &cobra.Command{
+	Use:     "show <도구> <카테고리>",
+	Aliases: []string{"get"},
+	Short:   "저장된 프롬프트 내용을 출력합니다",
+	Long: `저장된 프롬프트의 내용을 출력합니다.
+프로젝트, 팀, 사용자, 시스템 저장소 순서로 찾으며, 도구 전용 프롬프트가 없으면
+같은 카테고리의 공유(@shared) 프롬프트를 출력합니다. --scope로 범위 하나만 지정할 수 있습니다.
+--rev를 지정하면 'aide history'에 나오는 해당 리비전의 내용을 출력합니다 (기본값: 사용자 저장소).
+--resolved를 지정하면 포함({{include}})과 상속(--extends)을 펼치고 템플릿을 렌더링하여,
+'aide apply'가 실제로 적용할 내용을 출력합니다.
+
+예시:
+  aide show claude review            # 현재 프롬프트 출력
+  aide show claude review --rev 2    # 2번 리비전 출력
+  aide get cursor backend            # show의 별칭
+  aide show @shared backend          # 공유 프롬프트 출력
+  aide show claude review --resolved --var team=payments  # 적용될 내용 출력`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+		category := args[1]
+
+		// 지원되는 도구인지 확인
+		if err := checkPromptTool(tool); err != nil {
+			return err
+		}
+
+		prompt, err := readShownPrompt(tool, category)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(prompt)
+		if !strings.HasSuffix(prompt, "\n") {
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+// readShownPrompt는 --rev와 --scope에 따라 출력할 프롬프트를 읽습니다
+func readShownPrompt(tool, category string) (string, error) {
+	if showResolved {
+		if showScope != "" || showRevision > 0 {
+			return "", fmt.Errorf("--resolved는 --scope, --rev와 함께 사용할 수 없습니다")
+		}
+		layers, err := openCurrentLayers()
+		if err != nil {
+			return "", err
+		}
+		vars, err := currentTemplateVars()
+		if err != nil {
+			return "", err
+		}
+		section, err := resolveSection(layers, vars, tool, category)
+		return section.Prompt, err
+	}
+
+	if showScope == "" && showRevision == 0 {
+		layers, err := openCurrentLayers()
+		if err != nil {
+			return "", err
+		}
+		prompt, _, err := layers.ResolvePrompt(tool, category)
+		return prompt, err
+	}
+
+	store, err := openScope(showScope)
+	if err != nil {
+		return "", err
+	}
+	if showRevision > 0 {
+		return store.GetRevision(tool, category, showRevision)
+	}
+	return store.GetPrompt(tool, category)
+}
+
+func init() {
+	showCmd.Flags().IntVar(&showRevision, "rev", 0, "출력할 리비전 번호")
+	showCmd.Flags().StringVar(&showScope, "scope", "", "프롬프트를 읽을 저장소 범위 (project, team, user, system)")
+	showCmd.Flags().BoolVar(&showResolved, "resolved", false, "포함, 상속, 템플릿을 펼쳐 적용될 내용 출력")
+	addVarFlag(showCmd)
+	rootCmd.AddCommand(showCmd)
+}
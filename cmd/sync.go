@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hooneun/aide/internal/generators"
+	"github.com/hooneun/aide/internal/manifest"
+	"github.com/hooneun/aide/internal/registry"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncManifest string // 매니페스트 파일 경로 (비어 있으면 현재 디렉터리부터 상위로 검색)
+	syncDryRun   bool   // 파일을 쓰지 않고 변경 내용만 보여줄지 여부
+)
+
+// syncCmd는 프로젝트 매니페스트에 선언된 상태로 대상 파일을 맞추는 명령어입니다
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "프로젝트 매니페스트(.aide.yaml)에 선언된 프롬프트를 적용합니다",
+	Long: `프로젝트의 .aide.yaml에 선언된 도구와 카테고리를 읽어 모든 대상 파일을 선언된 상태로 맞춥니다.
+선언된 카테고리는 적용하고, 대상에 적용되어 있지만 선언에서 빠진 카테고리의 aide 영역은 제거합니다.
+(JSON/YAML/TOML 병합 형식은 상태 파일(<대상>.aide-state)에 기록된 카테고리의 키를 적용 전 값으로 되돌립니다.)
+
+.aide.yaml은 현재 디렉터리부터 상위 디렉터리로 올라가며 찾고, 대상 경로는 매니페스트가 있는 디렉터리 기준입니다.
+두 도구가 같은 대상 파일을 사용하도록 선언하면 적용하지 않고 오류로 알려줍니다.
+
+  tools:
+    claude: [review, backend]
+    cursor:
+      categories: [backend]
+      target: docs/.cursorrules   # 대상 경로 재정의 (선택사항)
+  vars:                           # 프롬프트 템플릿 변수 (선택사항, {{.team}})
+    team: payments
+
+예시:
+  aide sync                                   # 매니페스트대로 적용
+  aide sync --dry-run                         # 적용하지 않고 변경 내용만 확인
+  aide sync -f ci/.aide.yaml                  # 매니페스트 경로 지정`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		m, err := loadManifest(syncManifest)
+		if err != nil {
+			return err
+		}
+
+		plans, err := planManifest(m)
+		if err != nil {
+			return err
+		}
+
+		var changes []generators.FileChange
+		for _, plan := range plans {
+			changes = append(changes, plan.changes...)
+		}
+
+		if syncDryRun {
+			if !printChanges(changes) {
+				fmt.Println("모든 대상 파일이 매니페스트와 일치합니다.")
+				return nil
+			}
+			return exitWithCode(cmd, exitChangesPending)
+		}
+
+		if err := generators.WriteChanges(changes); err != nil {
+			return fmt.Errorf("프롬프트를 적용하는 중 오류가 발생했습니다: %w", err)
+		}
+
+		for _, plan := range plans {
+			fmt.Printf("%s (%s)\n", plan.tool, displayPath(plan.targetFile))
+			plan.printStatuses()
+		}
+		fmt.Printf("%s의 선언대로 %d개 도구를 동기화했습니다.\n", displayPath(m.Path), len(plans))
+
+		return nil
+	},
+}
+
+// loadManifest는 지정된 경로 또는 현재 디렉터리부터 찾은 매니페스트를 불러옵니다
+func loadManifest(path string) (*manifest.Manifest, error) {
+	if path == "" {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("현재 디렉터리를 가져올 수 없습니다: %w", err)
+		}
+		if path, err = manifest.Find(currentDir); err != nil {
+			if errors.Is(err, manifest.ErrNotFound) {
+				return nil, fmt.Errorf("%w (프로젝트 루트에 %s을 만들어 주세요)", err, manifest.FileName)
+			}
+			return nil, err
+		}
+	}
+	return manifest.Load(path)
+}
+
+// planManifest는 매니페스트에 선언된 도구마다 적용 계획을 계산합니다
+func planManifest(m *manifest.Manifest) ([]*applyPlan, error) {
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	// 매니페스트 디렉터리 기준 계층화된 저장소
+	layers, err := openLayers(m.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := templateVars(m.Dir, m)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := manifestTargets(reg, m)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*applyPlan, 0, len(targets))
+	for _, target := range targets {
+		plan, err := planTool(layers, vars, target.tool, target.path, target.entry.Categories, true)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", target.entry.Tool, err)
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// manifestTarget은 매니페스트 항목 하나의 도구와 대상 경로입니다
+type manifestTarget struct {
+	entry manifest.Entry
+	tool  *registry.Tool
+	path  string
+}
+
+// manifestTargets는 매니페스트 항목마다 도구와 대상 경로를 찾습니다.
+// 각 항목의 계획은 쓰기 전의 디스크 내용으로 계산되므로, 두 항목이 같은 대상을 쓰면 한쪽 변경이 사라집니다.
+// 이런 매니페스트는 오류로 거부합니다.
+func manifestTargets(reg *registry.Registry, m *manifest.Manifest) ([]manifestTarget, error) {
+	targets := make([]manifestTarget, 0, len(m.Tools))
+	owners := make(map[string]string, len(m.Tools))
+	for _, entry := range m.Tools {
+		toolDef, err := reg.Get(entry.Tool)
+		if err != nil {
+			return nil, err
+		}
+
+		path := m.TargetPath(entry)
+		if path == "" {
+			path = toolDef.Target(m.Dir)
+		}
+		path = filepath.Clean(path)
+
+		if owner, ok := owners[path]; ok {
+			return nil, fmt.Errorf("%s: 도구 '%s'와 '%s'가 같은 대상 %s을(를) 사용합니다. 한쪽에 target을 지정하거나 카테고리를 한 도구로 합치세요",
+				displayPath(m.Path), owner, entry.Tool, displayPath(path))
+		}
+		owners[path] = entry.Tool
+		targets = append(targets, manifestTarget{entry: entry, tool: toolDef, path: path})
+	}
+	return targets, nil
+}
+
+func init() {
+	syncCmd.Flags().StringVarP(&syncManifest, "file", "f", "", "매니페스트 파일 경로 (기본값: 현재 디렉터리부터 찾은 "+manifest.FileName+")")
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "파일을 쓰지 않고 변경 내용을 diff로 출력")
+	addVarFlag(syncCmd)
+	rootCmd.AddCommand(syncCmd)
+}
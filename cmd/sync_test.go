@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hooneun/aide/internal/manifest"
+	"github.com/hooneun/aide/internal/registry"
+	"github.com/hooneun/aide/internal/storage"
+)
+
+func TestManifestTargets(t *testing.T) {
+	store, err := storage.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg, err := registry.New(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+		errPart  string // 기대하는 오류 메시지 일부 (비어 있으면 성공)
+	}{
+		{
+			name:     "서로 다른 대상",
+			manifest: "tools:\n  claude: [review]\n  agents: [go]\n  cursor:\n    categories: [backend]\n    target: docs/.cursorrules\n",
+		},
+		{
+			name:     "재정의한 대상이 다른 도구의 기본 대상과 같음",
+			manifest: "tools:\n  agents: [go]\n  claude:\n    categories: [review]\n    target: AGENTS.md\n",
+			errPart:  "도구 'agents'와 'claude'가 같은 대상",
+		},
+		{
+			name:     "정리하면 같은 경로",
+			manifest: "tools:\n  claude:\n    categories: [review]\n    target: docs/RULES.md\n  agents:\n    categories: [go]\n    target: ./docs/../docs/RULES.md\n",
+			errPart:  "같은 대상",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), manifest.FileName)
+			if err := os.WriteFile(path, []byte(tt.manifest), 0644); err != nil {
+				t.Fatal(err)
+			}
+			m, err := manifest.Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			targets, err := manifestTargets(reg, m)
+			if tt.errPart != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+					t.Fatalf("오류에 %q가 있어야 하지만 %v", tt.errPart, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("예상치 못한 오류: %v", err)
+			}
+			if len(targets) != len(m.Tools) {
+				t.Fatalf("대상 수가 %d여야 하지만 %d", len(m.Tools), len(targets))
+			}
+		})
+	}
+}
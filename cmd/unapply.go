@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hooneun/aide/internal/generators"
+	"github.com/hooneun/aide/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// unapplyCmd는 적용된 프롬프트를 현재 프로젝트에서 제거하는 명령어입니다
+var unapplyCmd = &cobra.Command{
+	Use:   "unapply <도구> <카테고리>[,카테고리2,...]",
+	Short: "적용된 프롬프트를 현재 프로젝트에서 제거합니다",
+	Long: `'aide apply'로 적용한 프롬프트를 현재 프로젝트에서 제거합니다.
+aide가 표시한 카테고리 영역만 삭제하며, 영역 바깥에 직접 작성한 내용은 그대로 유지됩니다.
+마지막 aide 영역이 제거되면 aide 헤더도 함께 정리됩니다.
+JSON, YAML, TOML 병합 형식은 상태 파일(<대상>.aide-state)에 기록된 키만 적용 전 값으로 되돌리며,
+적용한 뒤 직접 바꾼 키는 그대로 둡니다.
+
+예시:
+  aide unapply claude review                  # Claude 리뷰 프롬프트 제거
+  aide unapply cursor backend,frontend        # 여러 프롬프트 동시 제거`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+
+		// 지원되는 도구인지 확인
+		toolDef, err := resolveTool(tool)
+		if err != nil {
+			return err
+		}
+
+		categories := splitCategories(args[1])
+		if len(categories) == 0 {
+			return fmt.Errorf("제거할 카테고리가 없습니다")
+		}
+		for _, category := range categories {
+			if err := storage.ValidateCategory(category); err != nil {
+				return err
+			}
+		}
+
+		sections := make([]generators.Section, 0, len(categories))
+		for _, category := range categories {
+			sections = append(sections, generators.Section{Category: category})
+		}
+
+		// 대상 파일 경로 가져오기
+		targetFile, err := toolTarget(toolDef)
+		if err != nil {
+			return err
+		}
+
+		// 파일 생성기 생성
+		generator, err := generators.NewGenerator(toolDef)
+		if err != nil {
+			return fmt.Errorf("파일 생성기를 초기화할 수 없습니다: %w", err)
+		}
+
+		removed, err := generators.Remove(generator, targetFile, sections)
+		if err != nil {
+			return fmt.Errorf("프롬프트를 제거하는 중 오류가 발생했습니다: %w", err)
+		}
+
+		if len(removed) == 0 {
+			fmt.Printf("%s에 적용된 aide 영역이 없습니다: %s\n", targetFile, strings.Join(categories, ", "))
+			return nil
+		}
+
+		fmt.Printf("프롬프트가 %s에서 제거되었습니다.\n", targetFile)
+		fmt.Printf("제거된 카테고리: %s\n", strings.Join(removed, ", "))
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(unapplyCmd)
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hooneun/aide/internal/manifest"
+	"github.com/hooneun/aide/internal/project"
+	"github.com/hooneun/aide/internal/render"
+
+	"github.com/spf13/cobra"
+)
+
+// varEnvPrefix는 템플릿 변수를 지정하는 환경 변수 접두사입니다 (AIDE_VAR_<이름>=값)
+const varEnvPrefix = "AIDE_VAR_"
+
+// templateVarFlags는 --var로 지정한 템플릿 변수 목록입니다 (이름=값)
+var templateVarFlags []string
+
+// addVarFlag는 템플릿 변수를 지정하는 --var 플래그를 추가합니다
+func addVarFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&templateVarFlags, "var", nil, "프롬프트 템플릿 변수 (이름=값, 여러 번 지정 가능)")
+}
+
+// promptVars는 프롬프트 템플릿에 사용할 변수입니다.
+// 프로젝트 정보(Module, Repo, Language)는 파일을 훑어야 하므로 템플릿이 참조할 때 처음 한 번만 찾습니다.
+type promptVars struct {
+	values     map[string]string // --var, 매니페스트, 환경 변수로 정의한 변수
+	projectDir string            // 프로젝트 정보를 찾을 디렉터리
+	detected   bool              // 프로젝트 정보를 찾았는지 여부
+}
+
+// forPrompt는 프롬프트를 렌더링할 변수를 반환합니다.
+// 프롬프트가 정의되지 않은 프로젝트 정보 변수를 참조하면 그때 프로젝트 정보를 찾아 채웁니다.
+func (v *promptVars) forPrompt(prompt string) map[string]string {
+	if v.detected {
+		return v.values
+	}
+
+	// 해석할 수 없는 템플릿은 렌더링할 때 오류를 보고하므로 여기서는 무시
+	refs, _ := render.Refs(prompt)
+	for _, name := range refs {
+		if _, defined := v.values[name]; defined || !containsString(project.VarNames, name) {
+			continue
+		}
+		for name, value := range project.Detect(v.projectDir).Vars() {
+			if _, defined := v.values[name]; !defined {
+				v.values[name] = value
+			}
+		}
+		v.detected = true
+		break
+	}
+	return v.values
+}
+
+// templateVars는 프롬프트 템플릿에 사용할 변수를 모읍니다.
+// 우선순위는 --var, 매니페스트의 vars, AIDE_VAR_ 환경 변수, 자동으로 찾은 프로젝트 정보 순서입니다.
+func templateVars(projectDir string, m *manifest.Manifest) (*promptVars, error) {
+	vars := make(map[string]string)
+
+	for _, env := range os.Environ() {
+		key, value, _ := strings.Cut(env, "=")
+		name, ok := strings.CutPrefix(key, varEnvPrefix)
+		if !ok {
+			continue
+		}
+		if err := render.ValidateName(name); err != nil {
+			return nil, fmt.Errorf("%s 환경 변수: %w", key, err)
+		}
+		vars[name] = value
+	}
+
+	if m != nil {
+		for name, value := range m.Vars {
+			vars[name] = value
+		}
+	}
+
+	for _, flag := range templateVarFlags {
+		name, value, ok := strings.Cut(flag, "=")
+		if !ok {
+			return nil, fmt.Errorf("--var는 이름=값 형식이어야 합니다: %s", flag)
+		}
+		if err := render.ValidateName(name); err != nil {
+			return nil, fmt.Errorf("--var: %w", err)
+		}
+		vars[name] = value
+	}
+	return &promptVars{values: vars, projectDir: projectDir}, nil
+}
+
+// renderPrompt는 프롬프트를 템플릿으로 렌더링합니다. Tool과 Category 변수는 따로 정의하지 않으면 자동으로 채웁니다.
+// 렌더링할 수 없으면 모든 오류에 --raw 안내를 덧붙입니다.
+func renderPrompt(tool, category, prompt string, vars *promptVars) (string, error) {
+	sectionVars := map[string]string{"Tool": tool, "Category": category}
+	for name, value := range vars.forPrompt(prompt) {
+		sectionVars[name] = value
+	}
+
+	rendered, err := render.Render(tool+"/"+category, prompt, sectionVars)
+	if err != nil {
+		var undefinedErr *render.UndefinedError
+		if errors.As(err, &undefinedErr) {
+			return "", fmt.Errorf("%s/%s: %w ('--var 이름=값', %s의 vars 또는 %s<이름> 환경 변수로 정의하거나, 템플릿이 아니면 'aide set --raw'로 표시하세요)",
+				tool, category, err, manifest.FileName, varEnvPrefix)
+		}
+		// ${{ secrets.TOKEN }}처럼 템플릿이 아닌 {{ }}는 해석이나 렌더링 중 오류가 나므로 --raw를 안내
+		return "", fmt.Errorf("%s/%s: %w (템플릿이 아니면 'aide set --raw'로 표시하세요)", tool, category, err)
+	}
+	return rendered, nil
+}
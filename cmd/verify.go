@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hooneun/aide/internal/generators"
+	"github.com/hooneun/aide/internal/manifest"
+	"github.com/hooneun/aide/internal/registry"
+	"github.com/hooneun/aide/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// verifyManifest는 매니페스트 파일 경로입니다 (비어 있으면 현재 디렉터리부터 상위로 검색)
+var verifyManifest string
+
+// verifyCmd는 적용된 aide 영역이 저장소 또는 매니페스트와 일치하는지 확인하는 명령어입니다
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "적용된 프롬프트가 저장소와 일치하는지 확인합니다",
+	Long: `프로젝트의 대상 파일에 있는 aide 영역을 현재 저장소가 생성할 내용과 비교합니다.
+파일은 수정하지 않으며, 차이가 있으면 파일별, 카테고리별로 출력하고 종료 코드 3으로 끝납니다.
+
+.aide.yaml이 있으면 매니페스트에 선언된 도구와 카테고리를 기준으로 확인하며,
+선언되었지만 적용되지 않은 카테고리와 적용되었지만 선언되지 않은 카테고리도 찾습니다.
+매니페스트가 없으면 대상 파일에 적용된 카테고리만 저장소와 비교합니다.
+(JSON/YAML/TOML 병합 형식은 상태 파일(<대상>.aide-state)에 기록된 카테고리를 적용된 카테고리로 봅니다.)
+
+찾는 차이:
+  프롬프트가 변경됨       저장소의 프롬프트가 바뀌었지만 다시 적용하지 않음
+  직접 수정됨             aide 영역 안쪽을 직접 수정함
+  적용되지 않음           매니페스트에 선언되었지만 대상에 없음
+  선언되지 않음           대상에 적용되었지만 매니페스트에 없음
+  저장소에 없음           적용된 카테고리의 프롬프트가 저장소에 없음
+
+예시:
+  aide verify                                 # CI에서 적용 누락과 직접 수정 확인
+  aide verify -f ci/.aide.yaml                # 매니페스트 경로 지정`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("현재 디렉터리를 가져올 수 없습니다: %w", err)
+		}
+
+		// 매니페스트가 있으면 선언 기준으로 확인
+		m, err := loadManifest(verifyManifest)
+		if err != nil {
+			if verifyManifest != "" || !errors.Is(err, manifest.ErrNotFound) {
+				return err
+			}
+			m = nil
+		}
+
+		reg, err := loadRegistry()
+		if err != nil {
+			return err
+		}
+
+		projectDir := currentDir
+		var targets []verifyTarget
+		if m != nil {
+			projectDir = m.Dir
+			declared, err := manifestTargets(reg, m)
+			if err != nil {
+				return err
+			}
+			for _, target := range declared {
+				targets = append(targets, verifyTarget{tool: target.tool, path: target.path, declared: target.entry.Categories})
+			}
+		}
+
+		// 매니페스트에 없는 도구도 기본 대상에 aide 영역이 있으면 확인
+		for _, toolDef := range reg.Tools() {
+			target := toolDef.Target(projectDir)
+			if !containsTarget(targets, target) {
+				targets = append(targets, verifyTarget{tool: toolDef, path: target, undeclared: m != nil})
+			}
+		}
+
+		// 프로젝트 기준 계층화된 저장소
+		layers, err := openLayers(projectDir)
+		if err != nil {
+			return err
+		}
+
+		// apply/sync와 같은 템플릿 변수로 비교
+		vars, err := templateVars(projectDir, m)
+		if err != nil {
+			return err
+		}
+
+		drifted, checked := 0, 0
+		for _, target := range targets {
+			result, err := target.verify(layers, vars)
+			if err != nil {
+				return fmt.Errorf("%s: %w", displayPath(target.path), err)
+			}
+			if result.categories == 0 {
+				continue // aide 영역이 없는 대상
+			}
+			checked++
+
+			if len(result.drifts) == 0 {
+				fmt.Printf("✓ %s (%s): %d개 카테고리 일치\n", displayPath(target.path), target.tool.Name, result.categories)
+				continue
+			}
+
+			drifted++
+			fmt.Printf("✗ %s (%s)\n", displayPath(target.path), target.tool.Name)
+			for _, drift := range result.drifts {
+				fmt.Printf("  %s: %s\n", drift.category, drift.reason)
+			}
+		}
+
+		if checked == 0 {
+			fmt.Println("확인할 aide 영역이 없습니다.")
+			return nil
+		}
+		if drifted > 0 {
+			fmt.Printf("\n%d개 파일이 저장소와 일치하지 않습니다. 'aide apply' 또는 'aide sync'로 다시 적용하세요.\n", drifted)
+			return exitWithCode(cmd, exitDriftDetected)
+		}
+		return nil
+	},
+}
+
+// verifyTarget은 확인할 도구의 대상 하나입니다
+type verifyTarget struct {
+	tool       *registry.Tool
+	path       string
+	declared   []string // 매니페스트에 선언된 카테고리 (nil이면 적용된 카테고리 기준)
+	undeclared bool     // 매니페스트에 없는 도구인지 여부 (적용된 모든 카테고리가 차이)
+}
+
+// categoryDrift는 카테고리 하나의 차이입니다
+type categoryDrift struct {
+	category string
+	reason   string
+}
+
+// verifyResult는 대상 하나의 확인 결과입니다
+type verifyResult struct {
+	categories int // 확인한 카테고리 수
+	drifts     []categoryDrift
+}
+
+// verify는 대상의 aide 영역을 저장소의 프롬프트와 비교합니다
+func (t verifyTarget) verify(layers *storage.Layers, vars *promptVars) (*verifyResult, error) {
+	generator, err := generators.NewGenerator(t.tool)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := generator.Applied(t.path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &verifyResult{}
+	if t.undeclared {
+		for _, category := range applied {
+			result.drifts = append(result.drifts, categoryDrift{category, "매니페스트에 선언되지 않았습니다"})
+		}
+		result.categories = len(applied)
+		return result, nil
+	}
+
+	categories := t.declared
+	if categories == nil {
+		categories = applied
+	}
+	result.categories = len(categories)
+
+	var sections []generators.Section
+	for _, category := range categories {
+		section, err := resolveSection(layers, vars, t.tool.Name, category)
+		if err != nil {
+			if _, _, resolveErr := layers.ResolvePrompt(t.tool.Name, category); resolveErr != nil {
+				result.drifts = append(result.drifts, categoryDrift{category, "저장소에 프롬프트가 없습니다"})
+				continue
+			}
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+
+	statuses, err := generator.Classify(t.path, sections)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		switch status.Status {
+		case generators.StatusNew:
+			result.drifts = append(result.drifts, categoryDrift{status.Category, "적용되지 않았습니다"})
+		case generators.StatusChanged:
+			result.drifts = append(result.drifts, categoryDrift{status.Category, "프롬프트가 변경되었습니다"})
+		case generators.StatusModified:
+			result.drifts = append(result.drifts, categoryDrift{status.Category, "aide 영역이 직접 수정되었습니다"})
+		}
+	}
+
+	// 적용되었지만 선언되지 않은 카테고리
+	if t.declared != nil {
+		for _, category := range applied {
+			if !containsString(t.declared, category) {
+				result.drifts = append(result.drifts, categoryDrift{category, "매니페스트에 선언되지 않았습니다"})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// containsTarget은 같은 경로의 대상이 이미 있는지 확인합니다
+func containsTarget(targets []verifyTarget, path string) bool {
+	for _, target := range targets {
+		if target.path == filepath.Clean(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	verifyCmd.Flags().StringVarP(&verifyManifest, "file", "f", "", "매니페스트 파일 경로 (기본값: 현재 디렉터리부터 찾은 "+manifest.FileName+")")
+	addVarFlag(verifyCmd)
+	rootCmd.AddCommand(verifyCmd)
+}
@@ -0,0 +1,203 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines는 hunk 앞뒤에 보여줄 변경되지 않은 줄 수입니다
+const contextLines = 3
+
+// opKind는 줄 단위 편집 종류입니다
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// op는 줄 하나에 대한 편집입니다
+type op struct {
+	kind opKind
+	line string
+	a, b int // 원본/새 내용에서의 줄 인덱스
+}
+
+// Unified는 두 내용의 차이를 unified diff 형식으로 반환합니다.
+// 내용이 같으면 빈 문자열을 반환합니다.
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	a, b := splitLines(oldText), splitLines(newText)
+	ops := compute(a, b)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n", oldName)
+	fmt.Fprintf(&out, "+++ %s\n", newName)
+
+	for _, h := range hunks(ops) {
+		writeHunk(&out, ops[h[0]:h[1]])
+	}
+
+	return out.String()
+}
+
+// noNewline은 줄바꿈으로 끝나지 않는 마지막 줄 뒤에 출력하는 표시입니다
+const noNewline = "\\ No newline at end of file\n"
+
+// splitLines는 내용을 줄바꿈을 포함한 줄 목록으로 나눕니다.
+// 마지막 줄은 줄바꿈이 없을 수 있으므로 끝의 줄바꿈만 다른 두 내용도 다른 줄로 비교됩니다.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// compute는 최장 공통 부분열로 편집 목록을 계산합니다.
+// 공통 앞부분과 뒷부분은 먼저 잘라 내어 바뀐 가운데 부분만 비교합니다.
+func compute(a, b []string) []op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]op, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, op{kind: opEqual, line: a[i], a: i, b: i})
+	}
+	ops = append(ops, computeLCS(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix, prefix)...)
+	for k := suffix; k > 0; k-- {
+		i, j := len(a)-k, len(b)-k
+		ops = append(ops, op{kind: opEqual, line: a[i], a: i, b: j})
+	}
+	return ops
+}
+
+// computeLCS는 a와 b의 편집 목록을 계산합니다. offA, offB는 원본/새 내용에서 a, b가 시작하는 줄 인덱스입니다.
+func computeLCS(a, b []string, offA, offB int) []op {
+	// lcs[i][j]는 a[i:]와 b[j:]의 최장 공통 부분열 길이
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, line: a[i], a: offA + i, b: offB + j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{kind: opDelete, line: a[i], a: offA + i, b: offB + j})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, line: b[j], a: offA + i, b: offB + j})
+			j++
+		}
+	}
+
+	return ops
+}
+
+// hunks는 변경된 줄 주변을 묶어 [시작, 끝) 범위 목록을 반환합니다
+func hunks(ops []op) [][2]int {
+	var result [][2]int
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == opEqual {
+			continue
+		}
+
+		start := max(i-contextLines, 0)
+		end := i
+		// 다음 변경까지의 간격이 context 두 배 이하이면 같은 hunk로 묶기
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == opEqual {
+				next++
+			}
+			if next == len(ops) || next-end > 2*contextLines {
+				end = min(end+contextLines, len(ops))
+				break
+			}
+			end = next
+		}
+
+		// 이전 hunk와 겹치면 합치기
+		if n := len(result); n > 0 && start <= result[n-1][1] {
+			result[n-1][1] = end
+		} else {
+			result = append(result, [2]int{start, end})
+		}
+		i = end - 1
+	}
+	return result
+}
+
+// writeHunk는 hunk 하나를 출력합니다
+func writeHunk(out *strings.Builder, ops []op) {
+	oldStart, newStart := ops[0].a, ops[0].b
+	oldCount, newCount := 0, 0
+	for _, o := range ops {
+		if o.kind != opInsert {
+			oldCount++
+		}
+		if o.kind != opDelete {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			out.WriteString(" ")
+		case opDelete:
+			out.WriteString("-")
+		case opInsert:
+			out.WriteString("+")
+		}
+		out.WriteString(o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			out.WriteString("\n" + noNewline)
+		}
+	}
+}
+
+// hunkRange는 hunk 헤더의 "시작,줄수" 부분을 만듭니다
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// 빈 범위는 직전 줄 번호로 표시
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -0,0 +1,285 @@
+package generators
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hooneun/aide/internal/registry"
+	"github.com/hooneun/aide/internal/storage"
+)
+
+// cursorGenerator는 Cursor의 두 가지 규칙 형식을 다룹니다.
+// 대상이 디렉터리(.cursor/rules)이면 카테고리마다 .mdc 규칙 파일을, 아니면 .cursorrules 파일 하나를 사용합니다.
+type cursorGenerator struct {
+	file  Generator
+	rules Generator
+}
+
+// newCursorGenerator는 Cursor 생성기를 생성합니다
+func newCursorGenerator() *cursorGenerator {
+	return &cursorGenerator{
+		file:  &fileGenerator{renderer: cursorLayout()},
+		rules: cursorRules(),
+	}
+}
+
+// cursorRules는 .cursor/rules/<카테고리>.mdc 파일을 만드는 생성기를 반환합니다
+func cursorRules() *ruleDirGenerator {
+	return &ruleDirGenerator{
+		tool:        "cursor",
+		style:       markdownStyle,
+		pattern:     storage.DefaultFilePattern,
+		extension:   ".mdc",
+		frontMatter: cursorFrontMatter,
+		usesMeta:    true,
+	}
+}
+
+// pick은 대상 경로에 맞는 생성기를 선택합니다
+func (g *cursorGenerator) pick(target string) Generator {
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		return g.rules
+	}
+	return g.file
+}
+
+// Classify는 대상에 적용된 카테고리별 상태를 판단합니다
+func (g *cursorGenerator) Classify(target string, sections []Section) ([]SectionStatus, error) {
+	return g.pick(target).Classify(target, sections)
+}
+
+// checkMode는 다른 형식에 aide 프롬프트가 남아 있으면 오류를 반환합니다.
+// 규칙 디렉터리에 적용할 때 .cursorrules에 aide 영역이 남아 있거나, .cursorrules에 적용할 때
+// 규칙 디렉터리에 aide 규칙 파일이 있으면 한쪽 영역이 관리되지 않은 채 남으므로 migrate-cursor를 안내합니다.
+func (g *cursorGenerator) checkMode(target string) error {
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		if !strings.HasSuffix(filepath.ToSlash(filepath.Clean(target)), "/"+registry.CursorRulesDir) {
+			return nil // 매니페스트로 지정한 다른 규칙 디렉터리
+		}
+		rulesFile := filepath.Join(filepath.Dir(filepath.Dir(target)), ".cursorrules")
+		applied, err := g.file.Applied(rulesFile)
+		if err != nil {
+			return err
+		}
+		if len(applied) > 0 {
+			return fmt.Errorf("%s에 aide 영역이 남아 있습니다 (%s). 'aide migrate-cursor'로 규칙 파일로 옮기세요", rulesFile, strings.Join(applied, ", "))
+		}
+		return nil
+	}
+
+	if filepath.Base(target) != ".cursorrules" {
+		return nil
+	}
+	rulesDir := filepath.Join(filepath.Dir(target), registry.CursorRulesDir)
+	applied, err := g.rules.Applied(rulesDir)
+	if err != nil {
+		return err
+	}
+	if len(applied) > 0 {
+		return fmt.Errorf("%s에 aide 규칙 파일이 있습니다 (%s). 'aide migrate-cursor'로 규칙 파일 사용을 기록하세요", rulesDir, strings.Join(applied, ", "))
+	}
+	return nil
+}
+
+// Plan은 대상에 섹션을 반영한 변경을 계산합니다
+func (g *cursorGenerator) Plan(target string, sections []Section) ([]FileChange, error) {
+	if err := g.checkMode(target); err != nil {
+		return nil, err
+	}
+	return g.pick(target).Plan(target, sections)
+}
+
+// PlanRemoval은 대상에서 섹션을 제거한 변경을 계산합니다
+func (g *cursorGenerator) PlanRemoval(target string, sections []Section) ([]FileChange, []string, error) {
+	return g.pick(target).PlanRemoval(target, sections)
+}
+
+// Applied는 대상에 적용된 카테고리 목록을 반환합니다
+func (g *cursorGenerator) Applied(target string) ([]string, error) {
+	return g.pick(target).Applied(target)
+}
+
+// Reconcile은 대상에서 stale 섹션을 제거하고 sections를 반영한 변경을 계산합니다
+func (g *cursorGenerator) Reconcile(target string, sections, stale []Section) ([]FileChange, error) {
+	if err := g.checkMode(target); err != nil {
+		return nil, err
+	}
+	return g.pick(target).Reconcile(target, sections, stale)
+}
+
+// cursorRulesMarkerContent는 규칙 디렉터리 전환 기록 파일의 내용입니다
+const cursorRulesMarkerContent = "# aide: cursor 프롬프트를 이 디렉터리의 .mdc 규칙 파일에 적용합니다 (aide migrate-cursor)\n"
+
+// MigrateCursorRules는 .cursorrules의 aide 영역을 규칙 디렉터리의 .mdc 파일로 옮기는 변경과
+// 옮겨지는 카테고리를 계산합니다. 영역 바깥에 직접 작성한 내용은 .cursorrules에 남습니다.
+// 각 카테고리는 apply와 같이 resolve로 프롬프트와 메타데이터를 찾아 .mdc 파일을 만들고,
+// 저장소에서 지운 프롬프트(storage.ErrPromptNotFound)만 영역 본문으로 옮깁니다.
+// 옮길 영역이 없어도 규칙 디렉터리에 전환 기록(registry.CursorRulesMarker)을 만들어,
+// 이후 apply가 .mdc 규칙 파일을 사용하도록 합니다. 이미 전환했고 옮길 영역도 없으면 변경이 없습니다.
+func MigrateCursorRules(rulesFile, rulesDir string, resolve func(category string) (Section, error)) ([]FileChange, []string, error) {
+	var changes []FileChange
+	markerPath := filepath.Join(rulesDir, registry.CursorRulesMarker)
+	if _, exists, err := readTarget(markerPath); err != nil {
+		return nil, nil, err
+	} else if !exists {
+		changes = append(changes, FileChange{Path: markerPath, After: cursorRulesMarkerContent})
+	}
+
+	existing, exists, err := readTarget(rulesFile)
+	if err != nil || !exists {
+		return changes, nil, err
+	}
+
+	layout := cursorLayout()
+	doc, err := parseDocument(existing, layout.style)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", rulesFile, err)
+	}
+
+	var sections []Section
+	var categories []string
+	for _, key := range doc.keysWithPrefix(layout.tool + "/") {
+		category := strings.TrimPrefix(key, layout.tool+"/")
+		section, err := resolve(category)
+		if errors.Is(err, storage.ErrPromptNotFound) {
+			body, _ := doc.body(key)
+			section, err = Section{Category: category, Prompt: body}, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s 프롬프트를 가져올 수 없습니다: %w", key, err)
+		}
+		sections = append(sections, section)
+		categories = append(categories, category)
+	}
+	if len(sections) == 0 {
+		return changes, nil, nil
+	}
+
+	rules, err := cursorRules().Plan(rulesDir, sections)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	removal, _, err := (&fileGenerator{renderer: layout}).PlanRemoval(rulesFile, sections)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(append(changes, rules...), removal...), categories, nil
+}
+
+// ruleMeta는 프롬프트 앞부분의 front matter에서 읽은 규칙 정보입니다
+type ruleMeta struct {
+	description string
+	globs       []string
+	alwaysApply *bool
+}
+
+// cursorFrontMatter는 프롬프트의 front matter로 .mdc 파일의 front matter를 만듭니다.
+// 프롬프트에 description이나 globs가 없으면 프롬프트 메타데이터를 사용하고, description이 그래도 없으면 카테고리 이름을 사용합니다.
+// alwaysApply가 없으면 globs가 없을 때만 true를 사용합니다.
+func cursorFrontMatter(section Section) (string, string, error) {
+	meta, body, err := splitFrontMatter(section.Prompt)
+	if err != nil {
+		return "", "", err
+	}
+
+	description := meta.description
+	if description == "" {
+		description = section.Description
+	}
+	if description == "" {
+		description = section.Category
+	}
+	if len(meta.globs) == 0 {
+		meta.globs = section.Globs
+	}
+	alwaysApply := len(meta.globs) == 0
+	if meta.alwaysApply != nil {
+		alwaysApply = *meta.alwaysApply
+	}
+
+	var header strings.Builder
+	header.WriteString("---\n")
+	header.WriteString("description: " + description + "\n")
+	header.WriteString(strings.TrimSpace("globs: "+strings.Join(meta.globs, ",")) + "\n")
+	header.WriteString("alwaysApply: " + strconv.FormatBool(alwaysApply) + "\n")
+	header.WriteString("---\n")
+	return header.String(), body, nil
+}
+
+// splitFrontMatter는 "---" 줄로 둘러싸인 프롬프트 앞부분의 front matter를 읽고 나머지 본문을 반환합니다.
+// front matter가 없으면 프롬프트 전체가 본문입니다.
+func splitFrontMatter(prompt string) (ruleMeta, string, error) {
+	var meta ruleMeta
+
+	lines := strings.Split(strings.TrimLeft(prompt, "\n"), "\n")
+	if strings.TrimSpace(lines[0]) != "---" {
+		return meta, prompt, nil
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return meta, "", fmt.Errorf("front matter가 '---'로 닫히지 않았습니다")
+	}
+
+	lastKey := ""
+	for _, line := range lines[1:end] {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// "globs:" 아래의 "- 패턴" 목록
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok && lastKey == "globs" {
+			meta.globs = append(meta.globs, unquoteMeta(item))
+			continue
+		}
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return meta, "", fmt.Errorf("front matter 줄을 해석할 수 없습니다: %s", trimmed)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		lastKey = key
+
+		switch key {
+		case "description":
+			meta.description = unquoteMeta(value)
+		case "globs":
+			value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			for _, glob := range strings.Split(value, ",") {
+				if glob = unquoteMeta(strings.TrimSpace(glob)); glob != "" {
+					meta.globs = append(meta.globs, glob)
+				}
+			}
+		case "alwaysApply":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return meta, "", fmt.Errorf("alwaysApply는 true 또는 false여야 합니다: %s", value)
+			}
+			meta.alwaysApply = &b
+		}
+	}
+
+	body := strings.TrimLeft(strings.Join(lines[end+1:], "\n"), "\n")
+	return meta, body, nil
+}
+
+// unquoteMeta는 front matter 값을 둘러싼 따옴표를 제거합니다
+func unquoteMeta(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
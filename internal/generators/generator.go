@@ -3,190 +3,435 @@ package generators
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
-	"time"
+
+	"github.com/hooneun/aide/internal/registry"
 	"github.com/hooneun/aide/internal/storage"
 )
 
-// Generator는 파일 생성기 인터페이스입니다
-type Generator interface {
-	Generate(filePath string, prompts []string) error
+// Section은 대상 파일에 적용할 카테고리 하나의 프롬프트입니다
+type Section struct {
+	Category string // 카테고리 이름
+	Prompt   string // 프롬프트 내용
+
+	// 프롬프트 메타데이터 (규칙 파일의 front matter 등에 사용, 선택사항)
+	Description string
+	Globs       []string
 }
 
-// ClaudeGenerator는 CLAUDE.md 파일을 생성합니다
-type ClaudeGenerator struct{}
+// FileChange는 생성기가 계산한 파일 하나의 변경 내용입니다
+type FileChange struct {
+	Path   string // 파일 경로
+	Before string // 현재 내용
+	After  string // 변경 후 내용 (Delete이면 빈 문자열)
+	Exists bool   // 현재 파일 존재 여부
+	Delete bool   // 파일을 삭제할지 여부
+
+	// Internal은 aide가 내부적으로 사용하는 상태 파일인지 여부입니다 (변경 내용 출력에서 제외)
+	Internal bool
 
-// CursorGenerator는 .cursorrules 파일을 생성합니다
-type CursorGenerator struct{}
+	// Root는 파일을 삭제한 뒤 비게 된 상위 디렉터리를 정리할 기준 디렉터리입니다 (Root 자체는 남김, 선택사항)
+	Root string
+}
+
+// Changed는 실제로 파일이 바뀌는지 확인합니다
+func (c FileChange) Changed() bool {
+	if c.Delete {
+		return c.Exists
+	}
+	return !c.Exists || c.Before != c.After
+}
+
+// Generator는 파일 생성기 인터페이스입니다.
+// target은 도구의 대상 경로이며, 생성기에 따라 파일 하나 또는 규칙 파일을 담는 디렉터리입니다.
+// 모든 메서드는 파일을 쓰지 않고 결과만 계산하며, 실제 반영은 WriteChanges가 담당합니다.
+// PlanRemoval은 섹션의 카테고리로 제거할 대상을 찾습니다.
+// Applied는 대상에 적용된 카테고리 목록을 반환합니다.
+// Reconcile은 stale 섹션을 제거한 뒤 sections를 반영하는 변경을 한 번에 계산합니다.
+type Generator interface {
+	Classify(target string, sections []Section) ([]SectionStatus, error)
+	Plan(target string, sections []Section) ([]FileChange, error)
+	PlanRemoval(target string, sections []Section) ([]FileChange, []string, error)
+	Applied(target string) ([]string, error)
+	Reconcile(target string, sections, stale []Section) ([]FileChange, error)
+}
+
+// contentRenderer는 파일 하나의 내용만으로 섹션을 반영하거나 제거하는 방법입니다
+type contentRenderer interface {
+	render(existing string, sections []Section) (string, error)
+	classify(existing string, sections []Section) ([]SectionStatus, error)
+	strip(existing string, sections []Section) (string, []string, error)
+	applied(existing string) ([]string, error)
+}
 
-// DynamicGenerator는 동적 도구 설정을 사용하는 생성기입니다
-type DynamicGenerator struct {
-	config *storage.ToolConfig
+// fileGenerator는 모든 섹션을 파일 하나에 기록하는 생성기입니다
+type fileGenerator struct {
+	renderer contentRenderer
 }
 
-// NewGenerator는 도구에 따른 적절한 생성기를 반환합니다
-func NewGenerator(tool string) (Generator, error) {
-	// 먼저 기본 도구들을 확인
-	switch tool {
-	case "claude":
-		return &ClaudeGenerator{}, nil
-	case "cursor":
-		return &CursorGenerator{}, nil
+// NewGenerator는 도구의 생성기 종류에 따른 생성기를 반환합니다
+func NewGenerator(tool *registry.Tool) (Generator, error) {
+	config := &tool.ToolConfig
+
+	switch tool.Kind {
+	case registry.KindClaude:
+		return &fileGenerator{renderer: claudeLayout()}, nil
+	case registry.KindCursor:
+		return newCursorGenerator(), nil
+	case registry.KindText:
+		return &fileGenerator{renderer: dynamicLayout(config)}, nil
+	case registry.KindDirectory:
+		return dirGenerator(config)
+	case registry.KindJSON:
+		return &MergeGenerator{config: config, codec: jsonCodec}, nil
+	case registry.KindYAML:
+		return &MergeGenerator{config: config, codec: yamlCodec}, nil
+	case registry.KindTOML:
+		return &MergeGenerator{config: config, codec: tomlCodec}, nil
 	default:
-		// 동적 도구 설정 확인
-		storage, err := storage.New()
-		if err != nil {
-			return nil, fmt.Errorf("저장소 초기화 실패: %w", err)
+		return nil, fmt.Errorf("지원되지 않는 파일 형식입니다: %s (도구: %s)", tool.Kind, tool.Name)
+	}
+}
+
+// Generate는 섹션을 대상에 반영합니다
+func Generate(g Generator, target string, sections []Section) error {
+	changes, err := g.Plan(target, sections)
+	if err != nil {
+		return err
+	}
+	return WriteChanges(changes)
+}
+
+// Remove는 대상에서 섹션을 제거하고 실제로 제거된 카테고리를 반환합니다
+func Remove(g Generator, target string, sections []Section) ([]string, error) {
+	changes, removed, err := g.PlanRemoval(target, sections)
+	if err != nil {
+		return nil, err
+	}
+	if err := WriteChanges(changes); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// WriteChanges는 계산된 변경을 파일에 반영합니다. 바뀌지 않는 파일은 건드리지 않습니다.
+func WriteChanges(changes []FileChange) error {
+	for _, change := range changes {
+		if !change.Changed() {
+			continue
 		}
 
-		config, err := storage.GetToolConfig(tool)
-		if err != nil {
-			return nil, fmt.Errorf("지원되지 않는 도구입니다: %s", tool)
+		if change.Delete {
+			if err := os.Remove(change.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("파일을 삭제할 수 없습니다: %w", err)
+			}
+			if change.Root != "" {
+				storage.RemoveEmptyDirs(filepath.Dir(change.Path), change.Root)
+			}
+			continue
+		}
+
+		// 상위 디렉터리가 없으면 생성
+		if err := os.MkdirAll(filepath.Dir(change.Path), 0755); err != nil {
+			return fmt.Errorf("디렉터리를 생성할 수 없습니다: %w", err)
 		}
 
-		return &DynamicGenerator{config: config}, nil
+		// 파일에 쓰기
+		if err := os.WriteFile(change.Path, []byte(change.After), 0644); err != nil {
+			return fmt.Errorf("파일을 저장할 수 없습니다: %w", err)
+		}
 	}
+	return nil
 }
 
-// Generate는 CLAUDE.md 파일을 생성하거나 업데이트합니다
-func (g *ClaudeGenerator) Generate(filePath string, prompts []string) error {
-	var content strings.Builder
-	
-	// 기존 파일이 있는지 확인
-	existingContent, err := os.ReadFile(filePath)
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("파일을 읽을 수 없습니다: %w", err)
+// readTarget은 파일 내용을 읽습니다. 파일이 없으면 빈 내용과 false를 반환합니다.
+func readTarget(filePath string) (string, bool, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("파일을 읽을 수 없습니다: %w", err)
 	}
+	return string(data), true, nil
+}
 
-	// 기존 내용이 있으면 추가, 없으면 새로 생성
-	if len(existingContent) > 0 {
-		content.WriteString(string(existingContent))
-		content.WriteString("\n\n")
+// Classify는 대상 파일에 적용된 카테고리별 상태를 판단합니다
+func (g *fileGenerator) Classify(target string, sections []Section) ([]SectionStatus, error) {
+	existing, _, err := readTarget(target)
+	if err != nil {
+		return nil, err
 	}
 
-	// aide로 추가된 섹션임을 표시
-	content.WriteString("# aide 프롬프트\n\n")
-	content.WriteString(fmt.Sprintf("다음 프롬프트는 aide에 의해 %s에 추가되었습니다.\n\n", time.Now().Format("2006-01-02 15:04:05")))
+	statuses, err := g.renderer.classify(existing, sections)
+	if err != nil {
+		return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
+	}
+	return statuses, nil
+}
 
-	// 각 프롬프트 추가
-	for i, prompt := range prompts {
-		if i > 0 {
-			content.WriteString("\n---\n\n")
-		}
-		content.WriteString(prompt)
-		content.WriteString("\n")
+// Plan은 대상 파일에 섹션을 반영한 변경을 계산합니다
+func (g *fileGenerator) Plan(target string, sections []Section) ([]FileChange, error) {
+	existing, exists, err := readTarget(target)
+	if err != nil {
+		return nil, err
 	}
 
-	// 파일에 쓰기
-	if err := os.WriteFile(filePath, []byte(content.String()), 0644); err != nil {
-		return fmt.Errorf("파일을 저장할 수 없습니다: %w", err)
+	content, err := g.renderer.render(existing, sections)
+	if err != nil {
+		return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
 	}
 
-	return nil
+	return []FileChange{{Path: target, Before: existing, After: content, Exists: exists}}, nil
 }
 
-// Generate는 .cursorrules 파일을 생성하거나 업데이트합니다
-func (g *CursorGenerator) Generate(filePath string, prompts []string) error {
-	var content strings.Builder
-	
-	// 기존 파일이 있는지 확인
-	existingContent, err := os.ReadFile(filePath)
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("파일을 읽을 수 없습니다: %w", err)
+// Applied는 대상 파일에 적용된 카테고리 목록을 반환합니다
+func (g *fileGenerator) Applied(target string) ([]string, error) {
+	existing, _, err := readTarget(target)
+	if err != nil {
+		return nil, err
 	}
 
-	// 기존 내용이 있으면 추가, 없으면 새로 생성
-	if len(existingContent) > 0 {
-		content.WriteString(string(existingContent))
-		content.WriteString("\n\n")
+	categories, err := g.renderer.applied(existing)
+	if err != nil {
+		return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
 	}
+	return categories, nil
+}
 
-	// aide로 추가된 섹션임을 표시
-	content.WriteString("# aide 프롬프트\n")
-	content.WriteString(fmt.Sprintf("# 다음 규칙은 aide에 의해 %s에 추가되었습니다.\n\n", time.Now().Format("2006-01-02 15:04:05")))
+// Reconcile은 대상 파일에서 stale 섹션을 제거하고 sections를 반영한 변경을 계산합니다
+func (g *fileGenerator) Reconcile(target string, sections, stale []Section) ([]FileChange, error) {
+	existing, exists, err := readTarget(target)
+	if err != nil {
+		return nil, err
+	}
 
-	// 각 프롬프트 추가
-	for i, prompt := range prompts {
-		if i > 0 {
-			content.WriteString("\n---\n\n")
+	content := existing
+	if len(stale) > 0 {
+		if content, _, err = g.renderer.strip(content, stale); err != nil {
+			return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
+		}
+	}
+	if len(sections) > 0 {
+		if content, err = g.renderer.render(content, sections); err != nil {
+			return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
 		}
-		content.WriteString(prompt)
-		content.WriteString("\n")
 	}
 
-	// 파일에 쓰기
-	if err := os.WriteFile(filePath, []byte(content.String()), 0644); err != nil {
-		return fmt.Errorf("파일을 저장할 수 없습니다: %w", err)
+	if content == "" && !exists {
+		return nil, nil // 만들 내용도 지울 파일도 없음
 	}
 
-	return nil
+	change := FileChange{Path: target, Before: existing, After: content, Exists: exists, Delete: content == ""}
+	return []FileChange{change}, nil
+}
+
+// PlanRemoval은 대상 파일에서 섹션을 제거한 변경을 계산합니다.
+// 제거 후 파일이 비어 있으면 파일을 삭제합니다.
+func (g *fileGenerator) PlanRemoval(target string, sections []Section) ([]FileChange, []string, error) {
+	existing, exists, err := readTarget(target)
+	if err != nil || !exists {
+		return nil, nil, err // 파일이 없으면 제거할 대상 없음
+	}
+
+	content, removed, err := g.renderer.strip(existing, sections)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
+	}
+
+	change := FileChange{Path: target, Before: existing, After: content, Exists: true, Delete: content == ""}
+	return []FileChange{change}, removed, nil
+}
+
+// regionLayout은 관리 영역 방식으로 파일을 생성하는 데 필요한 정보입니다.
+// 도구마다 헤더 영역 하나와 카테고리별 영역을 두며, 영역 바깥의 내용은 건드리지 않습니다.
+type regionLayout struct {
+	tool     string       // 영역 키에 사용할 도구 이름
+	style    commentStyle // 영역 표시 줄의 주석 형식
+	header   string       // aide 헤더 영역 내용
+	preamble string       // 새 파일을 만들 때 맨 앞에 쓰는 내용 (선택사항)
 }
 
-// CheckDuplicatePrompts는 중복된 프롬프트를 확인합니다
-func CheckDuplicatePrompts(filePath string, newPrompts []string) ([]string, error) {
-	// 기존 파일 내용 읽기
-	existingContent, err := os.ReadFile(filePath)
-	if err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("파일을 읽을 수 없습니다: %w", err)
+// headerKey는 헤더 영역의 키를 반환합니다
+func (l regionLayout) headerKey() string {
+	return l.tool
+}
+
+// sectionKey는 카테고리 영역의 키를 반환합니다
+func (l regionLayout) sectionKey(category string) string {
+	return l.tool + "/" + category
+}
+
+// render는 기존 파일 내용에 섹션을 반영한 새 내용을 반환합니다
+func (l regionLayout) render(existing string, sections []Section) (string, error) {
+	if existing == "" && l.preamble != "" {
+		existing = l.preamble + "\n"
 	}
 
-	existingText := string(existingContent)
-	var uniquePrompts []string
+	doc, err := parseDocument(existing, l.style)
+	if err != nil {
+		return "", err
+	}
 
-	// 새로운 프롬프트 중 중복되지 않은 것만 추가
-	for _, prompt := range newPrompts {
-		if !strings.Contains(existingText, strings.TrimSpace(prompt)) {
-			uniquePrompts = append(uniquePrompts, prompt)
+	// 도구의 영역이 하나도 없으면 헤더 영역부터 추가
+	if _, ok := doc.find(l.headerKey()); !ok && len(doc.keysWithPrefix(l.tool+"/")) == 0 {
+		if err := doc.insertAfter("", l.headerKey(), l.header); err != nil {
+			return "", err
 		}
 	}
 
-	return uniquePrompts, nil
+	for _, section := range sections {
+		key := l.sectionKey(section.Category)
+		hash := hashAttr + "=" + HashPrompt(section.Prompt)
+
+		// 이미 있는 영역은 제자리에서 교체
+		if _, ok := doc.find(key); ok {
+			if err := doc.replace(key, section.Prompt, hash); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		// 새 영역은 도구의 마지막 영역 뒤에 추가
+		if err := doc.insertAfter(l.lastKey(doc), key, section.Prompt, hash); err != nil {
+			return "", err
+		}
+	}
+
+	return doc.String(), nil
 }
 
-// Generate는 동적 도구 설정을 사용하여 파일을 생성하거나 업데이트합니다
-func (g *DynamicGenerator) Generate(filePath string, prompts []string) error {
-	var content strings.Builder
-	
-	// 기존 파일이 있는지 확인
-	existingContent, err := os.ReadFile(filePath)
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("파일을 읽을 수 없습니다: %w", err)
+// applied는 문서에 카테고리 영역이 있는 카테고리 목록을 반환합니다
+func (l regionLayout) applied(existing string) ([]string, error) {
+	doc, err := parseDocument(existing, l.style)
+	if err != nil {
+		return nil, err
 	}
 
-	// 기존 내용이 있으면 추가, 없으면 새로 생성
-	if len(existingContent) > 0 {
-		content.WriteString(string(existingContent))
-		content.WriteString("\n\n")
-	} else if g.config.Header != "" {
-		// 새 파일인 경우 헤더 추가
-		content.WriteString(g.config.Header)
-		content.WriteString("\n\n")
+	var categories []string
+	for _, key := range doc.keysWithPrefix(l.tool + "/") {
+		categories = append(categories, strings.TrimPrefix(key, l.tool+"/"))
 	}
+	return categories, nil
+}
 
-	// aide로 추가된 섹션임을 표시
-	separator := g.config.Separator
-	if separator == "" {
-		separator = "# ---" // 기본 구분자
+// lastKey는 문서에서 이 도구에 속한 마지막 영역의 키를 반환합니다
+func (l regionLayout) lastKey(doc *document) string {
+	last := ""
+	if _, ok := doc.find(l.headerKey()); ok {
+		last = l.headerKey()
+	}
+	if keys := doc.keysWithPrefix(l.tool + "/"); len(keys) > 0 {
+		last = keys[len(keys)-1]
+	}
+	return last
+}
+
+// classify는 카테고리 영역에 기록된 프롬프트 해시와 비교하여 적용 상태를 판단합니다.
+// 기록된 해시가 프롬프트와 같아도 영역 내용이 달라졌으면 직접 수정된 것으로 봅니다.
+func (l regionLayout) classify(existing string, sections []Section) ([]SectionStatus, error) {
+	doc, err := parseDocument(existing, l.style)
+	if err != nil {
+		return nil, err
 	}
-	
-	content.WriteString(separator)
-	content.WriteString("\n")
-	content.WriteString(fmt.Sprintf("# %s - aide에 의해 %s에 추가됨\n\n", 
-		g.config.Description, time.Now().Format("2006-01-02 15:04:05")))
 
-	// 각 프롬프트 추가
-	for i, prompt := range prompts {
-		if i > 0 {
-			content.WriteString("\n" + separator + "\n\n")
+	result := make([]SectionStatus, 0, len(sections))
+	for _, section := range sections {
+		key := l.sectionKey(section.Category)
+
+		status := StatusNew
+		if _, ok := doc.find(key); ok {
+			status = StatusChanged
+			if hash := HashPrompt(section.Prompt); doc.attr(key, hashAttr) == hash {
+				status = StatusUnchanged
+				if body, _ := doc.body(key); HashPrompt(body) != hash {
+					status = StatusModified
+				}
+			}
 		}
-		content.WriteString(prompt)
-		content.WriteString("\n")
+		result = append(result, SectionStatus{Section: section, Status: status})
 	}
+	return result, nil
+}
 
-	// 파일에 쓰기
-	if err := os.WriteFile(filePath, []byte(content.String()), 0644); err != nil {
-		return fmt.Errorf("파일을 저장할 수 없습니다: %w", err)
+// strip은 기존 파일 내용에서 카테고리 영역을 제거한 새 내용과 실제로 제거된 카테고리를 반환합니다.
+// 도구의 카테고리 영역이 모두 사라지면 헤더 영역도 함께 제거합니다.
+func (l regionLayout) strip(existing string, sections []Section) (string, []string, error) {
+	doc, err := parseDocument(existing, l.style)
+	if err != nil {
+		return "", nil, err
 	}
 
-	return nil
-}
\ No newline at end of file
+	var removed []string
+	for _, section := range sections {
+		ok, err := doc.remove(l.sectionKey(section.Category))
+		if err != nil {
+			return "", nil, err
+		}
+		if ok {
+			removed = append(removed, section.Category)
+		}
+	}
+
+	// 마지막 aide 영역이 사라졌다면 헤더도 정리
+	if len(doc.keysWithPrefix(l.tool+"/")) == 0 {
+		if _, err := doc.remove(l.headerKey()); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return doc.String(), removed, nil
+}
+
+// claudeLayout은 CLAUDE.md의 영역 구성을 반환합니다
+func claudeLayout() regionLayout {
+	return regionLayout{
+		tool:   "claude",
+		style:  markdownStyle,
+		header: "# aide 프롬프트\n\n다음 프롬프트는 aide가 관리합니다. aide 표시 안쪽 내용은 다시 적용할 때 교체됩니다.",
+	}
+}
+
+// cursorLayout은 .cursorrules의 영역 구성을 반환합니다
+func cursorLayout() regionLayout {
+	return regionLayout{
+		tool:   "cursor",
+		style:  hashStyle,
+		header: "# aide 프롬프트\n# 다음 규칙은 aide가 관리합니다. aide 표시 안쪽 내용은 다시 적용할 때 교체됩니다.",
+	}
+}
+
+// styleFromSeparator는 구분자의 첫 토큰으로 주석 형식을 추정합니다
+func styleFromSeparator(separator string) commentStyle {
+	fields := strings.Fields(separator)
+	if len(fields) == 0 {
+		return hashStyle
+	}
+
+	switch token := fields[0]; {
+	case token == markdownStyle.open:
+		return markdownStyle
+	case strings.Trim(token, "-=*_") == "":
+		// "---"처럼 구분선만 있는 경우
+		return hashStyle
+	default:
+		return commentStyle{open: token}
+	}
+}
+
+// dynamicLayout은 동적 도구 설정에 따른 영역 구성을 반환합니다
+func dynamicLayout(config *storage.ToolConfig) regionLayout {
+	separator := config.Separator
+	if separator == "" {
+		separator = "# ---" // 기본 구분자
+	}
+	style := styleFromSeparator(separator)
+
+	return regionLayout{
+		tool:     config.Name,
+		style:    style,
+		header:   separator + "\n" + strings.TrimSpace(fmt.Sprintf("%s %s - aide가 관리하는 영역입니다 %s", style.open, config.Description, style.close)),
+		preamble: config.Header,
+	}
+}
@@ -0,0 +1,904 @@
+package generators
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hooneun/aide/internal/registry"
+	"github.com/hooneun/aide/internal/storage"
+)
+
+func TestClaudeGenerator_ReapplyReplacesRegion(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	filePath := filepath.Join(tmpDir, "CLAUDE.md")
+	userContent := "# 프로젝트 메모\n\n직접 작성한 내용입니다.\n"
+	if err := os.WriteFile(filePath, []byte(userContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	generator := &fileGenerator{renderer: claudeLayout()}
+
+	// 처음 적용
+	err = Generate(generator, filePath, []Section{
+		{Category: "review", Prompt: "이전 리뷰 프롬프트"},
+		{Category: "backend", Prompt: "백엔드 프롬프트"},
+	})
+	if err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+
+	// 수정된 프롬프트로 다시 적용
+	err = Generate(generator, filePath, []Section{{Category: "review", Prompt: "새 리뷰 프롬프트"}})
+	if err != nil {
+		t.Fatalf("프롬프트 재적용 실패: %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+
+	if !strings.HasPrefix(content, userContent) {
+		t.Errorf("사용자 내용이 유지되지 않았습니다:\n%s", content)
+	}
+	if strings.Contains(content, "이전 리뷰 프롬프트") {
+		t.Errorf("이전 프롬프트가 남아 있습니다:\n%s", content)
+	}
+	if strings.Count(content, "새 리뷰 프롬프트") != 1 || strings.Count(content, "백엔드 프롬프트") != 1 {
+		t.Errorf("프롬프트가 정확히 한 번씩 포함되어야 합니다:\n%s", content)
+	}
+	if strings.Count(content, "# aide 프롬프트") != 1 {
+		t.Errorf("헤더가 한 번만 포함되어야 합니다:\n%s", content)
+	}
+	if strings.Index(content, "새 리뷰 프롬프트") > strings.Index(content, "백엔드 프롬프트") {
+		t.Errorf("교체된 영역이 원래 위치에 있어야 합니다:\n%s", content)
+	}
+
+	// 같은 내용으로 다시 적용해도 파일이 바뀌지 않아야 함
+	err = Generate(generator, filePath, []Section{{Category: "review", Prompt: "새 리뷰 프롬프트"}})
+	if err != nil {
+		t.Fatalf("프롬프트 재적용 실패: %v", err)
+	}
+	again, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != content {
+		t.Errorf("같은 프롬프트를 다시 적용하면 내용이 같아야 합니다.\n예상:\n%s\n실제:\n%s", content, again)
+	}
+}
+
+func TestDynamicGenerator_UsesSeparatorCommentStyle(t *testing.T) {
+	layout := dynamicLayout(&storage.ToolConfig{
+		Name:        "windsurf",
+		Description: "Windsurf 규칙 파일",
+		Header:      "// Windsurf 규칙",
+		Separator:   "// ---",
+	})
+
+	content, err := layout.render("", []Section{{Category: "go", Prompt: "Go 규칙"}})
+	if err != nil {
+		t.Fatalf("렌더링 실패: %v", err)
+	}
+
+	expected := "// Windsurf 규칙\n\n" +
+		"// aide:begin windsurf\n// ---\n// Windsurf 규칙 파일 - aide가 관리하는 영역입니다\n// aide:end windsurf\n\n" +
+		"// aide:begin windsurf/go sha256=" + HashPrompt("Go 규칙") + "\nGo 규칙\n// aide:end windsurf/go\n"
+	if content != expected {
+		t.Errorf("생성된 내용이 일치하지 않습니다.\n예상:\n%s\n실제:\n%s", expected, content)
+	}
+}
+
+func TestParseDocument_UnclosedRegion(t *testing.T) {
+	_, err := parseDocument("<!-- aide:begin claude/review -->\n내용\n", markdownStyle)
+	if err == nil {
+		t.Error("닫히지 않은 영역은 오류를 반환해야 합니다")
+	}
+}
+
+func TestCursorGenerator_RemoveCleansUpHeader(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	filePath := filepath.Join(tmpDir, ".cursorrules")
+	userContent := "직접 작성한 규칙\n"
+	if err := os.WriteFile(filePath, []byte(userContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	generator := &fileGenerator{renderer: cursorLayout()}
+	err = Generate(generator, filePath, []Section{
+		{Category: "backend", Prompt: "백엔드 규칙"},
+		{Category: "frontend", Prompt: "프론트엔드 규칙"},
+	})
+	if err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+
+	// 하나만 제거하면 헤더는 남아 있어야 함
+	removed, err := Remove(generator, filePath, []Section{{Category: "backend"}, {Category: "missing"}})
+	if err != nil {
+		t.Fatalf("프롬프트 제거 실패: %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "backend" {
+		t.Errorf("제거된 카테고리가 일치하지 않습니다: %v", removed)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "백엔드 규칙") || !strings.Contains(string(data), "# aide 프롬프트") {
+		t.Errorf("예상하지 못한 내용입니다:\n%s", data)
+	}
+
+	// 마지막 영역을 제거하면 사용자 내용만 남아야 함
+	if _, err := Remove(generator, filePath, []Section{{Category: "frontend"}}); err != nil {
+		t.Fatalf("프롬프트 제거 실패: %v", err)
+	}
+
+	data, err = os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != userContent {
+		t.Errorf("사용자 내용만 남아야 합니다.\n예상:\n%s\n실제:\n%s", userContent, data)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	layout := claudeLayout()
+
+	existing, err := layout.render("", []Section{
+		{Category: "review", Prompt: "보안 취약점을\n체크해줘"},
+		{Category: "backend", Prompt: "에러 처리에 집중해줘"},
+	})
+	if err != nil {
+		t.Fatalf("렌더링 실패: %v", err)
+	}
+
+	statuses, err := layout.classify(existing, []Section{
+		{Category: "review", Prompt: "  보안 취약점을 체크해줘\n"}, // 공백만 다름
+		{Category: "backend", Prompt: "에러"},              // 기존 프롬프트의 일부
+		{Category: "frontend", Prompt: "에러 처리에 집중해줘"},    // 다른 카테고리와 같은 내용
+	})
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+
+	expected := []Status{StatusUnchanged, StatusChanged, StatusNew}
+	for i, status := range statuses {
+		if status.Status != expected[i] {
+			t.Errorf("%s의 상태가 일치하지 않습니다. 예상: %s, 실제: %s", status.Category, expected[i], status.Status)
+		}
+	}
+}
+
+func TestMergeGenerator_JSONMergesAndRemoves(t *testing.T) {
+	generator := &MergeGenerator{config: &storage.ToolConfig{Name: "vscode", Format: storage.FormatJSON}, codec: jsonCodec}
+
+	existing := "{\n    \"files.eol\": \"\\n\",\n    \"editor\": {\n        \"fontSize\": 14\n    }\n}\n"
+	sections := []Section{{Category: "formatting", Prompt: `{"editor": {"tabSize": 2}, "editor.formatOnSave": true}`}}
+
+	state := newMergeState()
+	content, err := generator.render(existing, state, sections)
+	if err != nil {
+		t.Fatalf("렌더링 실패: %v", err)
+	}
+
+	expected := "{\n    \"files.eol\": \"\\n\",\n    \"editor\": {\n        \"fontSize\": 14,\n        \"tabSize\": 2\n    },\n    \"editor.formatOnSave\": true\n}\n"
+	if content != expected {
+		t.Errorf("병합 결과가 일치하지 않습니다.\n예상:\n%s\n실제:\n%s", expected, content)
+	}
+
+	statuses, err := generator.classify(content, state, sections)
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+	if statuses[0].Status != StatusUnchanged {
+		t.Errorf("병합 후에는 이미 적용된 상태여야 합니다: %s", statuses[0].Status)
+	}
+
+	stripped, removed, err := generator.strip(content, state, sections)
+	if err != nil {
+		t.Fatalf("제거 실패: %v", err)
+	}
+	if len(removed) != 1 || stripped != existing {
+		t.Errorf("제거 후 원래 내용으로 돌아가야 합니다.\n예상:\n%s\n실제:\n%s", existing, stripped)
+	}
+}
+
+func TestMergeGenerator_JSONInvalidPrompt(t *testing.T) {
+	generator := &MergeGenerator{config: &storage.ToolConfig{Name: "vscode", Format: storage.FormatJSON}, codec: jsonCodec}
+
+	_, err := generator.render("{}", newMergeState(), []Section{{Category: "broken", Prompt: "탭 크기는 2"}})
+	if err == nil || !strings.Contains(err.Error(), "vscode/broken") {
+		t.Errorf("잘못된 JSON 프롬프트는 카테고리를 포함한 오류를 반환해야 합니다: %v", err)
+	}
+}
+
+func TestMergeGenerator_UnapplyRestoresPreviousValues(t *testing.T) {
+	generator := &MergeGenerator{config: &storage.ToolConfig{Name: "vscode", Format: storage.FormatJSON}, codec: jsonCodec}
+	target := filepath.Join(t.TempDir(), "settings.json")
+
+	// 사용자가 이미 같은 값으로 설정한 키와 aide가 바꿀 키
+	original := "{\n  \"editor.formatOnSave\": false,\n  \"editor.tabSize\": 4,\n  \"files\": {\n    \"eol\": \"\\n\"\n  }\n}\n"
+	if err := os.WriteFile(target, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	base := Section{Category: "base", Prompt: `{"editor.formatOnSave": false, "editor.tabSize": 2, "files": {"trimTrailingWhitespace": true}}`}
+	strict := Section{Category: "strict", Prompt: `{"editor.tabSize": 8, "lint": {"strict": true}}`}
+	if err := Generate(generator, target, []Section{base}); err != nil {
+		t.Fatalf("적용 실패: %v", err)
+	}
+	if err := Generate(generator, target, []Section{strict}); err != nil {
+		t.Fatalf("적용 실패: %v", err)
+	}
+	if _, err := os.Stat(statePath(target)); err != nil {
+		t.Fatalf("상태 파일이 없습니다: %v", err)
+	}
+
+	applied, err := generator.Applied(target)
+	if err != nil || strings.Join(applied, ",") != "base,strict" {
+		t.Errorf("적용된 카테고리가 일치하지 않습니다: %v, %v", applied, err)
+	}
+
+	// 먼저 적용한 카테고리를 제거해도 나중에 적용한 값은 유지
+	if _, err := Remove(generator, target, []Section{{Category: "base"}}); err != nil {
+		t.Fatalf("제거 실패: %v", err)
+	}
+	content, _, _ := readTarget(target)
+	expected := "{\n  \"editor.formatOnSave\": false,\n  \"editor.tabSize\": 8,\n  \"files\": {\n    \"eol\": \"\\n\"\n  },\n  \"lint\": {\n    \"strict\": true\n  }\n}\n"
+	if content != expected {
+		t.Errorf("base 제거 결과가 일치하지 않습니다.\n예상:\n%s\n실제:\n%s", expected, content)
+	}
+
+	// 마지막 카테고리를 제거하면 원래 내용으로 돌아가고 상태 파일도 삭제
+	removed, err := Remove(generator, target, []Section{{Category: "strict"}})
+	if err != nil || len(removed) != 1 {
+		t.Fatalf("제거 실패: %v, %v", removed, err)
+	}
+	if content, _, _ := readTarget(target); content != original {
+		t.Errorf("원래 내용으로 돌아가야 합니다.\n예상:\n%s\n실제:\n%s", original, content)
+	}
+	if _, err := os.Stat(statePath(target)); !os.IsNotExist(err) {
+		t.Errorf("상태 파일이 삭제되어야 합니다: %v", err)
+	}
+
+	// 기록이 없는 카테고리는 제거하지 않음
+	if removed, _ := Remove(generator, target, []Section{{Category: "base"}}); len(removed) != 0 {
+		t.Errorf("적용되지 않은 카테고리를 제거했습니다: %v", removed)
+	}
+}
+
+func TestMergeGenerator_ReapplyDropsRemovedKeys(t *testing.T) {
+	generator := &MergeGenerator{config: &storage.ToolConfig{Name: "vscode", Format: storage.FormatJSON}, codec: jsonCodec}
+	target := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(target, []byte("{\n  \"files.eol\": \"\\n\"\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Generate(generator, target, []Section{{Category: "go", Prompt: `{"editor.tabSize": 2, "go.lint": "x"}`}}); err != nil {
+		t.Fatalf("적용 실패: %v", err)
+	}
+
+	// 프롬프트에서 키를 빼고 다시 적용
+	edited := []Section{{Category: "go", Prompt: `{"editor.tabSize": 8}`}}
+	statuses, err := generator.Classify(target, edited)
+	if err != nil || statuses[0].Status != StatusChanged {
+		t.Errorf("키가 빠진 프롬프트는 변경됨이어야 합니다: %v, %v", statuses, err)
+	}
+	if err := Generate(generator, target, edited); err != nil {
+		t.Fatalf("다시 적용 실패: %v", err)
+	}
+
+	content, _, _ := readTarget(target)
+	if expected := "{\n  \"files.eol\": \"\\n\",\n  \"editor.tabSize\": 8\n}\n"; content != expected {
+		t.Errorf("프롬프트에서 빠진 키는 제거되어야 합니다.\n예상:\n%s\n실제:\n%s", expected, content)
+	}
+	stateContent, _, _ := readTarget(statePath(target))
+	if strings.Contains(stateContent, "go.lint") {
+		t.Errorf("상태 파일에 빠진 키의 기록이 남으면 안 됩니다:\n%s", stateContent)
+	}
+
+	if _, err := Remove(generator, target, []Section{{Category: "go"}}); err != nil {
+		t.Fatalf("제거 실패: %v", err)
+	}
+	if content, _, _ := readTarget(target); content != "{\n  \"files.eol\": \"\\n\"\n}\n" {
+		t.Errorf("제거하면 원래 내용으로 돌아가야 합니다:\n%s", content)
+	}
+}
+
+func TestMergeGenerator_ClassifyUsesState(t *testing.T) {
+	generator := &MergeGenerator{config: &storage.ToolConfig{Name: "vscode", Format: storage.FormatJSON}, codec: jsonCodec}
+	target := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(target, []byte(`{"editor.tabSize": 4, "editor.formatOnSave": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sections := []Section{
+		{Category: "overrides", Prompt: `{"editor.tabSize": 2}`},    // 사용자 키를 바꾸는 프롬프트
+		{Category: "same", Prompt: `{"editor.formatOnSave": true}`}, // 이미 같은 값이 있는 프롬프트
+	}
+	statuses, err := generator.Classify(target, sections)
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+	for _, status := range statuses {
+		if status.Status != StatusNew {
+			t.Errorf("기록되지 않은 %s는 새로 적용할 대상이어야 합니다: %s", status.Category, status.Status)
+		}
+	}
+
+	if err := Generate(generator, target, sections); err != nil {
+		t.Fatalf("적용 실패: %v", err)
+	}
+	if applied, err := generator.Applied(target); err != nil || len(applied) != 2 {
+		t.Errorf("같은 값이던 프롬프트도 상태에 기록되어야 합니다: %v, %v", applied, err)
+	}
+	statuses, err = generator.Classify(target, sections)
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+	for _, status := range statuses {
+		if status.Status != StatusUnchanged {
+			t.Errorf("적용한 뒤 %s는 이미 적용됨이어야 합니다: %s", status.Category, status.Status)
+		}
+	}
+}
+
+func TestMergeGenerator_UnapplyKeepsUserEdits(t *testing.T) {
+	generator := &MergeGenerator{config: &storage.ToolConfig{Name: "aider"}, codec: yamlCodec}
+	target := filepath.Join(t.TempDir(), ".aider.conf.yml")
+
+	section := Section{Category: "base", Prompt: "model: o3\nlint:\n  cmd: go vet\n"}
+	if err := Generate(generator, target, []Section{section}); err != nil {
+		t.Fatalf("적용 실패: %v", err)
+	}
+
+	// 적용한 뒤 사용자가 직접 바꾼 값
+	content, _, _ := readTarget(target)
+	if err := os.WriteFile(target, []byte(strings.Replace(content, "model: o3", "model: gpt-4o", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Remove(generator, target, []Section{{Category: "base"}}); err != nil {
+		t.Fatalf("제거 실패: %v", err)
+	}
+	if content, _, _ := readTarget(target); content != "model: gpt-4o\n" {
+		t.Errorf("직접 바꾼 값은 유지되어야 합니다:\n%s", content)
+	}
+
+	// aide가 만든 파일은 비면 삭제
+	if err := os.Remove(target); err != nil {
+		t.Fatal(err)
+	}
+	if err := Generate(generator, target, []Section{section}); err != nil {
+		t.Fatalf("적용 실패: %v", err)
+	}
+	if _, err := Remove(generator, target, []Section{{Category: "base"}}); err != nil {
+		t.Fatalf("제거 실패: %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("aide가 만든 파일은 삭제되어야 합니다: %v", err)
+	}
+}
+
+func TestMergeGenerator_YAMLAndTOML(t *testing.T) {
+	tests := []struct {
+		name     string
+		codec    codec
+		existing string
+		prompt   string
+		expected string
+	}{
+		{
+			name:     "YAML",
+			codec:    yamlCodec,
+			existing: "model: gpt-4o\nread:\n  - README.md\n",
+			prompt:   "read:\n  - CONVENTIONS.md\nauto-commits: false\n",
+			expected: "model: gpt-4o\nread:\n  - CONVENTIONS.md\nauto-commits: false\n",
+		},
+		{
+			name:     "TOML",
+			codec:    tomlCodec,
+			existing: "model = \"gpt-4o\"\n\n[lint]\ncmd = \"go vet\"\n",
+			prompt:   "[lint]\nauto = true\n",
+			expected: "model = \"gpt-4o\"\n\n[lint]\ncmd = \"go vet\"\nauto = true\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MergeGenerator{config: &storage.ToolConfig{Name: "aider"}, codec: tt.codec}
+
+			content, err := generator.render(tt.existing, newMergeState(), []Section{{Category: "base", Prompt: tt.prompt}})
+			if err != nil {
+				t.Fatalf("렌더링 실패: %v", err)
+			}
+			if content != tt.expected {
+				t.Errorf("병합 결과가 일치하지 않습니다.\n예상:\n%s\n실제:\n%s", tt.expected, content)
+			}
+		})
+	}
+}
+
+func TestMergeGenerator_UnapplyRestoresOriginalText(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		codec     codec
+		fileName  string
+		input     string
+		prompt    string
+		reapplied string // 다시 적용할 때의 프롬프트 (일부 키를 뺌)
+	}{
+		{
+			name:      "YAML",
+			format:    storage.FormatYAML,
+			codec:     yamlCodec,
+			fileName:  "config.yml",
+			input:     "# config\nempty:\nmodel: 'gpt-4o'  # main model\neditor:\n  theme: \"dark\" # theme\n  font: mono\nroles: [chat, edit]\nblock: |\n  keep\n",
+			prompt:    "empty: x\nmodel: claude\neditor:\n  theme: light\nroles: [review]\nblock: short\nadded: 1\n",
+			reapplied: "empty: y\neditor:\n  theme: light\nblock: short\n",
+		},
+		{
+			name:      "TOML",
+			format:    storage.FormatTOML,
+			codec:     tomlCodec,
+			fileName:  "config.toml",
+			input:     "# config\nz = 'lit'\nmulti = \"\"\"\nline\"\"\"\nroles = [ 'chat' ]  # roles\n\n[editor]\ntheme = '''dark''' # theme\nfont = \"mono\"\n",
+			prompt:    "z = \"new\"\nmulti = \"one\"\nroles = [\"review\"]\nadded = true\n\n[editor]\ntheme = \"light\"\n",
+			reapplied: "z = \"other\"\nroles = [\"review\"]\n\n[editor]\ntheme = \"light\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MergeGenerator{config: &storage.ToolConfig{Name: "tool", Format: tt.format}, codec: tt.codec}
+			target := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(target, []byte(tt.input), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := Generate(generator, target, []Section{{Category: "go", Prompt: tt.prompt}}); err != nil {
+				t.Fatalf("적용 실패: %v", err)
+			}
+			if err := Generate(generator, target, []Section{{Category: "go", Prompt: tt.reapplied}}); err != nil {
+				t.Fatalf("다시 적용 실패: %v", err)
+			}
+			if _, err := Remove(generator, target, []Section{{Category: "go"}}); err != nil {
+				t.Fatalf("제거 실패: %v", err)
+			}
+
+			if content, _, _ := readTarget(target); content != tt.input {
+				t.Errorf("적용 후 제거하면 원본과 바이트 단위로 같아야 합니다.\n예상:\n%s\n실제:\n%s", tt.input, content)
+			}
+			if _, exists, _ := readTarget(statePath(target)); exists {
+				t.Error("마지막 카테고리를 제거하면 상태 파일도 삭제되어야 합니다")
+			}
+		})
+	}
+}
+
+func TestMergeGenerator_PreservesCommentsAndFlowStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		codec    codec
+		existing string
+		prompt   string
+		applied  string
+	}{
+		{
+			name:     "YAML",
+			codec:    yamlCodec,
+			existing: "# my aider config\nmodel: gpt-4o  # main model\nroles: [chat, edit]\n",
+			prompt:   "model: o3\nroles: [chat, review]\n",
+			applied:  "# my aider config\nmodel: o3  # main model\nroles: [chat, review]\n",
+		},
+		{
+			name:     "TOML",
+			codec:    tomlCodec,
+			existing: "# top comment\nmodel = \"gpt-4o\" # main model\n\n[lint]\ncmd = \"go vet\"\n",
+			prompt:   "model = \"o3\"\n\n[lint]\nauto = true\n",
+			applied:  "# top comment\nmodel = \"o3\" # main model\n\n[lint]\ncmd = \"go vet\"\nauto = true\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MergeGenerator{config: &storage.ToolConfig{Name: "aider"}, codec: tt.codec}
+			target := filepath.Join(t.TempDir(), "config")
+			if err := os.WriteFile(target, []byte(tt.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := Generate(generator, target, []Section{{Category: "base", Prompt: tt.prompt}}); err != nil {
+				t.Fatalf("적용 실패: %v", err)
+			}
+			if content, _, _ := readTarget(target); content != tt.applied {
+				t.Errorf("주석과 서식이 유지되어야 합니다.\n예상:\n%s\n실제:\n%s", tt.applied, content)
+			}
+
+			if _, err := Remove(generator, target, []Section{{Category: "base"}}); err != nil {
+				t.Fatalf("제거 실패: %v", err)
+			}
+			if content, _, _ := readTarget(target); content != tt.existing {
+				t.Errorf("제거하면 원본으로 돌아가야 합니다.\n예상:\n%s\n실제:\n%s", tt.existing, content)
+			}
+		})
+	}
+}
+
+func TestMigrateCursorRules(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	rulesFile := filepath.Join(tmpDir, ".cursorrules")
+	rulesDir := filepath.Join(tmpDir, ".cursor", "rules")
+	userContent := "직접 작성한 규칙\n"
+	if err := os.WriteFile(rulesFile, []byte(userContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sections := []Section{
+		{Category: "backend", Prompt: "---\ndescription: Go 백엔드\nglobs:\n  - \"**/*.go\"\n---\n에러를 감싸서 반환해줘"},
+		{Category: "style", Prompt: "간결하게"},
+	}
+	if err := Generate(newCursorGenerator(), rulesFile, sections); err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+
+	changes, categories, err := MigrateCursorRules(rulesFile, rulesDir, resolveFrom(sections))
+	if err != nil {
+		t.Fatalf("규칙 분리 실패: %v", err)
+	}
+	if len(categories) != 2 {
+		t.Errorf("옮긴 카테고리가 일치하지 않습니다: %v", categories)
+	}
+	if err := WriteChanges(changes); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(rulesDir, "backend.mdc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "---\ndescription: Go 백엔드\nglobs: **/*.go\nalwaysApply: false\n---\n\n" +
+		"<!-- aide:begin cursor/backend sha256=" + HashPrompt(sections[0].Prompt) + " -->\n에러를 감싸서 반환해줘\n<!-- aide:end cursor/backend -->\n"
+	if string(data) != expected {
+		t.Errorf("규칙 파일 내용이 일치하지 않습니다.\n예상:\n%s\n실제:\n%s", expected, data)
+	}
+
+	data, err = os.ReadFile(rulesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != userContent {
+		t.Errorf(".cursorrules에는 사용자 내용만 남아야 합니다:\n%s", data)
+	}
+
+	// 규칙 디렉터리가 대상이면 옮긴 규칙은 이미 적용된 상태여야 함
+	statuses, err := newCursorGenerator().Classify(rulesDir, sections)
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+	for _, status := range statuses {
+		if status.Status != StatusUnchanged {
+			t.Errorf("%s의 상태가 이미 적용됨이어야 합니다: %s", status.Category, status.Status)
+		}
+	}
+
+	// aide가 관리하지 않는 규칙 파일은 덮어쓰지 않아야 함
+	if err := os.WriteFile(filepath.Join(rulesDir, "manual.mdc"), []byte("직접 만든 규칙\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newCursorGenerator().Plan(rulesDir, []Section{{Category: "manual", Prompt: "덮어쓰기"}}); err == nil {
+		t.Error("aide가 관리하지 않는 규칙 파일은 오류를 반환해야 합니다")
+	}
+}
+
+func TestMigrateCursorRules_UsesPromptMetaThenVerifies(t *testing.T) {
+	tmpDir := t.TempDir()
+	rulesFile := filepath.Join(tmpDir, ".cursorrules")
+	rulesDir := filepath.Join(tmpDir, ".cursor", "rules")
+
+	sections := []Section{
+		{Category: "backend", Prompt: "에러를 감싸서 반환해줘", Description: "Go 백엔드 규칙", Globs: []string{"**/*.go"}},
+		{Category: "removed", Prompt: "저장소에서 지운 프롬프트"},
+	}
+	if err := Generate(newCursorGenerator(), rulesFile, sections); err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+
+	// removed는 저장소에서 지워져 영역 본문으로 옮겨져야 함
+	changes, _, err := MigrateCursorRules(rulesFile, rulesDir, resolveFrom(sections[:1]))
+	if err != nil {
+		t.Fatalf("규칙 분리 실패: %v", err)
+	}
+	if err := WriteChanges(changes); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(rulesDir, "backend.mdc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "---\ndescription: Go 백엔드 규칙\nglobs: **/*.go\nalwaysApply: false\n---\n") {
+		t.Errorf("프롬프트 메타데이터로 front matter를 만들어야 합니다:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(rulesDir, "removed.mdc")); err != nil {
+		t.Errorf("지운 프롬프트도 영역 본문으로 옮겨야 합니다: %v", err)
+	}
+
+	// verify와 같이 apply할 섹션으로 상태를 판단하면 바로 적용됨이어야 함
+	statuses, err := newCursorGenerator().Classify(rulesDir, sections[:1])
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+	if statuses[0].Status != StatusUnchanged {
+		t.Errorf("옮긴 직후 verify는 변경이 없어야 합니다: %s", statuses[0].Status)
+	}
+
+	// 저장소 오류는 그대로 반환
+	otherDir := t.TempDir()
+	otherFile := filepath.Join(otherDir, ".cursorrules")
+	if err := Generate(newCursorGenerator(), otherFile, sections[:1]); err != nil {
+		t.Fatal(err)
+	}
+	failing := func(string) (Section, error) { return Section{}, os.ErrPermission }
+	if _, _, err := MigrateCursorRules(otherFile, filepath.Join(otherDir, ".cursor", "rules"), failing); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("프롬프트를 찾는 중 오류는 반환해야 합니다: %v", err)
+	}
+}
+
+// resolveFrom은 sections에서 카테고리를 찾는 resolve 함수를 반환합니다
+func resolveFrom(sections []Section) func(string) (Section, error) {
+	return func(category string) (Section, error) {
+		for _, section := range sections {
+			if section.Category == category {
+				return section, nil
+			}
+		}
+		return Section{}, fmt.Errorf("%w: cursor/%s", storage.ErrPromptNotFound, category)
+	}
+}
+
+func TestCursorGenerator_RefusesMixedModes(t *testing.T) {
+	projectDir := t.TempDir()
+	rulesFile := filepath.Join(projectDir, ".cursorrules")
+	rulesDir := filepath.Join(projectDir, ".cursor", "rules")
+	sections := []Section{{Category: "backend", Prompt: "에러를 감싸서 반환해줘"}}
+
+	if err := Generate(newCursorGenerator(), rulesFile, sections); err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+
+	// 직접 만든 규칙 디렉터리만으로는 .cursorrules의 영역을 버려두고 전환하지 않아야 함
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newCursorGenerator().Plan(rulesDir, sections); err == nil || !strings.Contains(err.Error(), "migrate-cursor") {
+		t.Errorf(".cursorrules에 aide 영역이 남아 있으면 규칙 디렉터리에 적용하지 않아야 합니다: %v", err)
+	}
+
+	// 옮긴 뒤에는 전환 기록이 남고, .cursorrules에 다시 적용하지 않아야 함
+	changes, _, err := MigrateCursorRules(rulesFile, rulesDir, resolveFrom(sections))
+	if err != nil {
+		t.Fatalf("규칙 분리 실패: %v", err)
+	}
+	if err := WriteChanges(changes); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(rulesDir, registry.CursorRulesMarker)); err != nil {
+		t.Errorf("전환 기록 파일이 있어야 합니다: %v", err)
+	}
+	if _, err := newCursorGenerator().Plan(rulesDir, sections); err != nil {
+		t.Errorf("옮긴 뒤에는 규칙 디렉터리에 적용할 수 있어야 합니다: %v", err)
+	}
+	if _, err := newCursorGenerator().Plan(rulesFile, sections); err == nil {
+		t.Error("규칙 디렉터리에 aide 규칙 파일이 있으면 .cursorrules에 적용하지 않아야 합니다")
+	}
+
+	// 이미 전환했고 옮길 영역이 없으면 변경 없음
+	if changes, categories, err := MigrateCursorRules(rulesFile, rulesDir, resolveFrom(sections)); err != nil || len(changes) != 0 || len(categories) != 0 {
+		t.Errorf("다시 옮기면 변경이 없어야 합니다: %v, %v, %v", changes, categories, err)
+	}
+}
+
+func TestDirGenerator_FilePerCategory(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	generator, err := dirGenerator(&storage.ToolConfig{
+		Name:        "cline",
+		Header:      "# Cline 규칙",
+		Output:      storage.OutputDirectory,
+		FilePattern: "{tool}-{category}",
+		Extension:   "txt",
+	})
+	if err != nil {
+		t.Fatalf("생성기 초기화 실패: %v", err)
+	}
+
+	dir := filepath.Join(tmpDir, ".clinerules")
+	sections := []Section{{Category: "go", Prompt: "Go 규칙"}, {Category: "ts", Prompt: "TS 규칙"}}
+	if err := Generate(generator, dir, sections); err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "cline-go.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "# Cline 규칙\n\n<!-- aide:begin cline/go sha256=" + HashPrompt("Go 규칙") + " -->\nGo 규칙\n<!-- aide:end cline/go -->\n"
+	if string(data) != expected {
+		t.Errorf("규칙 파일 내용이 일치하지 않습니다.\n예상:\n%s\n실제:\n%s", expected, data)
+	}
+
+	removed, err := Remove(generator, dir, []Section{{Category: "go"}, {Category: "missing"}})
+	if err != nil {
+		t.Fatalf("프롬프트 제거 실패: %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "go" {
+		t.Errorf("제거된 카테고리가 일치하지 않습니다: %v", removed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cline-go.txt")); !os.IsNotExist(err) {
+		t.Error("제거된 규칙 파일이 남아 있습니다")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cline-ts.txt")); err != nil {
+		t.Errorf("다른 규칙 파일은 유지되어야 합니다: %v", err)
+	}
+
+	if _, err := dirGenerator(&storage.ToolConfig{Name: "broken", Output: storage.OutputDirectory, FilePattern: "rules"}); err == nil {
+		t.Error("{category}가 없는 패턴은 오류를 반환해야 합니다")
+	}
+}
+
+func TestReconcile_RemovesStaleAndApplies(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	generator := &fileGenerator{renderer: claudeLayout()}
+	filePath := filepath.Join(tmpDir, "CLAUDE.md")
+	if err := Generate(generator, filePath, []Section{{Category: "old", Prompt: "예전 프롬프트"}}); err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+
+	applied, err := generator.Applied(filePath)
+	if err != nil || len(applied) != 1 || applied[0] != "old" {
+		t.Fatalf("적용된 카테고리가 일치하지 않습니다: %v, %v", applied, err)
+	}
+
+	changes, err := generator.Reconcile(filePath, []Section{{Category: "review", Prompt: "리뷰 프롬프트"}}, []Section{{Category: "old"}})
+	if err != nil {
+		t.Fatalf("동기화 계산 실패: %v", err)
+	}
+	if len(changes) != 1 || strings.Contains(changes[0].After, "예전 프롬프트") || !strings.Contains(changes[0].After, "리뷰 프롬프트") {
+		t.Errorf("예상하지 못한 변경입니다: %+v", changes)
+	}
+
+	// 디렉터리 출력은 파일 단위로 적용된 카테고리를 찾음
+	dirGen, err := dirGenerator(&storage.ToolConfig{Name: "rules", Output: storage.OutputDirectory})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(tmpDir, "rules")
+	if err := Generate(dirGen, dir, []Section{{Category: "go/errors", Prompt: "에러 규칙"}}); err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+	applied, err = dirGen.Applied(dir)
+	if err != nil || len(applied) != 1 || applied[0] != "go/errors" {
+		t.Errorf("적용된 카테고리가 일치하지 않습니다: %v, %v", applied, err)
+	}
+	if applied, err := dirGen.Applied(filepath.Join(tmpDir, "missing")); err != nil || applied != nil {
+		t.Errorf("없는 디렉터리는 빈 목록이어야 합니다: %v, %v", applied, err)
+	}
+
+	// 중첩 카테고리를 제거하면 비게 된 하위 디렉터리도 정리하되 대상 디렉터리는 남김
+	if _, err := Remove(dirGen, dir, []Section{{Category: "go/errors"}}); err != nil {
+		t.Fatalf("제거 실패: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go")); !os.IsNotExist(err) {
+		t.Errorf("빈 하위 디렉터리는 삭제되어야 합니다: %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("대상 디렉터리는 남아야 합니다: %v", err)
+	}
+}
+
+func TestClassify_DetectsHandEdits(t *testing.T) {
+	layout := claudeLayout()
+	sections := []Section{{Category: "review", Prompt: "보안 취약점을 체크해줘"}}
+
+	existing, err := layout.render("", sections)
+	if err != nil {
+		t.Fatalf("렌더링 실패: %v", err)
+	}
+	edited := strings.Replace(existing, "체크해줘", "꼼꼼히 체크해줘", 1)
+
+	statuses, err := layout.classify(edited, sections)
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+	if statuses[0].Status != StatusModified {
+		t.Errorf("직접 수정된 영역은 %s 상태여야 합니다: %s", StatusModified, statuses[0].Status)
+	}
+
+	// 규칙 파일도 같은 방식으로 판단
+	tmpDir := t.TempDir()
+	rules := cursorRules()
+	if err := Generate(rules, tmpDir, sections); err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+	filePath := filepath.Join(tmpDir, "review.mdc")
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(strings.Replace(string(data), "alwaysApply: true", "alwaysApply: false", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err = rules.Classify(tmpDir, sections)
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+	if statuses[0].Status != StatusModified {
+		t.Errorf("직접 수정된 규칙 파일은 %s 상태여야 합니다: %s", StatusModified, statuses[0].Status)
+	}
+}
+
+func TestCursorRules_UsesPromptMeta(t *testing.T) {
+	tmpDir := t.TempDir()
+	rules := cursorRules()
+
+	section := Section{Category: "backend", Prompt: "에러는 감싸서 반환해줘", Description: "Go 백엔드", Globs: []string{"**/*.go"}}
+	if err := Generate(rules, tmpDir, []Section{section}); err != nil {
+		t.Fatalf("프롬프트 적용 실패: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "backend.mdc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "---\ndescription: Go 백엔드\nglobs: **/*.go\nalwaysApply: false\n---\n") {
+		t.Errorf("메타데이터가 front matter에 반영되지 않았습니다:\n%s", data)
+	}
+
+	// 메타데이터만 바뀌어도 다시 적용해야 함
+	section.Description = "Go 서버"
+	statuses, err := rules.Classify(tmpDir, []Section{section})
+	if err != nil {
+		t.Fatalf("상태 판단 실패: %v", err)
+	}
+	if statuses[0].Status != StatusChanged {
+		t.Errorf("메타데이터가 바뀐 규칙 파일은 %s 상태여야 합니다: %s", StatusChanged, statuses[0].Status)
+	}
+}
@@ -0,0 +1,308 @@
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hooneun/aide/internal/storage"
+	"github.com/hooneun/aide/internal/tree"
+)
+
+// codec은 구조화된 설정 파일 형식의 파싱과 출력 방법입니다
+type codec struct {
+	name   string
+	parse  func(data string) (*tree.Map, error)
+	encode func(doc *tree.Map, existing string, originals tree.Originals) (string, error)
+	// source는 문서에서 키 경로의 원본 텍스트를 찾습니다 (nil이면 원본 텍스트를 기록하지 않음)
+	source func(data string, path []string) (string, bool)
+}
+
+// jsonCodec은 JSON 문서를 다룹니다. 기존 문서의 들여쓰기를 유지합니다.
+var jsonCodec = codec{
+	name:  "JSON",
+	parse: tree.ParseJSON,
+	encode: func(doc *tree.Map, existing string, _ tree.Originals) (string, error) {
+		return tree.EncodeJSON(doc, tree.DetectJSONIndent(existing)), nil
+	},
+}
+
+// yamlCodec은 YAML 문서를 다룹니다. 기존 문서의 바뀐 키만 고쳐 써서 주석과 서식을 유지합니다.
+var yamlCodec = codec{
+	name:  "YAML",
+	parse: tree.ParseYAML,
+	encode: func(doc *tree.Map, existing string, originals tree.Originals) (string, error) {
+		return tree.PatchYAML(existing, doc, originals)
+	},
+	source: tree.YAMLSource,
+}
+
+// tomlCodec은 TOML 문서를 다룹니다. 기존 문서의 바뀐 키만 고쳐 써서 주석과 서식을 유지합니다.
+var tomlCodec = codec{
+	name:  "TOML",
+	parse: tree.ParseTOML,
+	encode: func(doc *tree.Map, existing string, originals tree.Originals) (string, error) {
+		return tree.PatchTOML(existing, doc, originals)
+	},
+	source: tree.TOMLSource,
+}
+
+// MergeGenerator는 프롬프트를 설정 객체로 해석하여 대상 문서에 깊게 병합합니다.
+// 기존 키의 순서와 관련 없는 키는 그대로 유지됩니다.
+// 병합하며 바꾼 키와 그 전 값은 대상 파일 옆의 상태 파일(<대상>.aide-state)에 카테고리별로 기록하고,
+// 제거할 때는 aide가 바꾼 키만 병합 전 값으로 되돌립니다.
+type MergeGenerator struct {
+	config *storage.ToolConfig
+	codec  codec
+}
+
+// parsePrompt는 섹션의 프롬프트를 설정 객체로 파싱합니다
+func (g *MergeGenerator) parsePrompt(section Section) (*tree.Map, error) {
+	m, err := g.codec.parse(section.Prompt)
+	if err != nil {
+		return nil, fmt.Errorf("프롬프트 %s/%s는 %s 객체여야 합니다: %w", g.config.Name, section.Category, g.codec.name, err)
+	}
+	return m, nil
+}
+
+// parseTarget은 대상 파일 내용을 파싱합니다. 빈 파일은 빈 객체로 취급합니다.
+func (g *MergeGenerator) parseTarget(existing string) (*tree.Map, error) {
+	if strings.TrimSpace(existing) == "" {
+		return tree.NewMap(), nil
+	}
+	return g.codec.parse(existing)
+}
+
+// mergeTarget은 대상 파일과 상태 파일을 읽은 결과입니다
+type mergeTarget struct {
+	path         string
+	content      string
+	exists       bool
+	state        *mergeState
+	stateContent string
+	stateExists  bool
+}
+
+// read는 대상 파일과 상태 파일을 읽습니다. 상태 파일이 없으면 빈 상태를 사용합니다.
+func (g *MergeGenerator) read(target string) (*mergeTarget, error) {
+	content, exists, err := readTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	t := &mergeTarget{path: target, content: content, exists: exists, state: newMergeState()}
+
+	t.stateContent, t.stateExists, err = readTarget(statePath(target))
+	if err != nil || !t.stateExists {
+		return t, err
+	}
+	m, err := g.parseTarget(t.stateContent)
+	if err == nil {
+		t.state, err = stateFromMap(m)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", statePath(target), err)
+	}
+	return t, nil
+}
+
+// changes는 대상 파일과 상태 파일의 변경을 만듭니다.
+// 적용된 카테고리가 없으면 상태 파일을 삭제하고, aide가 만든 대상 파일이 비면 대상 파일도 삭제합니다.
+func (g *MergeGenerator) changes(t *mergeTarget, content string) ([]FileChange, error) {
+	target := FileChange{Path: t.path, Before: t.content, After: content, Exists: t.exists, Delete: content == "" && t.content != ""}
+	state := FileChange{Path: statePath(t.path), Before: t.stateContent, Exists: t.stateExists, Internal: true}
+	if len(t.state.categories) == 0 {
+		state.Delete = true
+	} else {
+		encoded, err := g.codec.encode(t.state.toMap(), t.stateContent, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s을(를) 저장할 수 없습니다: %w", state.Path, err)
+		}
+		state.After = encoded
+	}
+	return []FileChange{target, state}, nil
+}
+
+// render는 프롬프트 객체를 기존 문서에 병합한 내용을 반환하고, 바뀌는 키를 상태에 기록합니다
+func (g *MergeGenerator) render(existing string, state *mergeState, sections []Section) (string, error) {
+	doc, err := g.parseTarget(existing)
+	if err != nil {
+		return "", err
+	}
+	if len(state.categories) == 0 {
+		state.created = strings.TrimSpace(existing) == ""
+	}
+
+	originals := tree.Originals{}
+	for _, section := range sections {
+		prompt, err := g.parsePrompt(section)
+		if err != nil {
+			return "", err
+		}
+		// 다시 적용하면 이전에 병합한 키를 먼저 되돌려, 프롬프트에서 빠진 키가 남지 않도록 함
+		var previous []mergeEntry
+		if state.has(section.Category) {
+			previous = state.restore(section.Category, doc)
+			addOriginals(originals, previous)
+		}
+		state.record(section.Category, doc, prompt, g.sourceOf(existing, previous))
+		tree.Merge(doc, prompt)
+	}
+
+	return g.codec.encode(doc, existing, originals)
+}
+
+// sourceOf는 병합 전 값의 원본 텍스트를 찾는 함수를 반환합니다.
+// 다시 적용할 때는 existing에 aide가 쓴 값이 있으므로, 같은 값을 기록한 이전 기록의 원본 텍스트를 먼저 사용합니다.
+func (g *MergeGenerator) sourceOf(existing string, previous []mergeEntry) func(path []string, value any) string {
+	if g.codec.source == nil {
+		return nil
+	}
+	return func(path []string, value any) string {
+		for _, entry := range previous {
+			if entry.existed && samePath(entry.path, path) && tree.Equal(entry.previous, value) {
+				return entry.source
+			}
+		}
+		text, _ := g.codec.source(existing, path)
+		return text
+	}
+}
+
+// addOriginals는 기록의 병합 전 값 원본 텍스트를 모읍니다
+func addOriginals(originals tree.Originals, entries []mergeEntry) {
+	for _, entry := range entries {
+		if entry.existed && entry.source != "" {
+			originals.Set(entry.path, entry.source)
+		}
+	}
+}
+
+// classify는 상태 파일의 기록으로 적용 상태를 판단합니다.
+// 기록되지 않은 카테고리는 문서에 같은 값이 있어도 새로 적용할 대상이며,
+// 기록된 카테고리는 기록한 키와 값이 프롬프트와 같을 때만 이미 적용된 상태입니다.
+func (g *MergeGenerator) classify(existing string, state *mergeState, sections []Section) ([]SectionStatus, error) {
+	doc, err := g.parseTarget(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]SectionStatus, 0, len(sections))
+	for _, section := range sections {
+		prompt, err := g.parsePrompt(section)
+		if err != nil {
+			return nil, err
+		}
+
+		status := StatusChanged
+		switch {
+		case !state.has(section.Category):
+			status = StatusNew
+		case tree.Contains(doc, prompt) && state.sameKeys(section.Category, doc, prompt):
+			status = StatusUnchanged
+		}
+		result = append(result, SectionStatus{Section: section, Status: status})
+	}
+	return result, nil
+}
+
+// strip은 상태에 기록된 카테고리의 키를 병합 전 값으로 되돌린 내용과 실제로 제거된 카테고리를 반환합니다.
+// 프롬프트 내용은 사용하지 않으므로 저장소에서 지운 프롬프트도 제거할 수 있습니다.
+func (g *MergeGenerator) strip(existing string, state *mergeState, sections []Section) (string, []string, error) {
+	doc, err := g.parseTarget(existing)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var removed []string
+	originals := tree.Originals{}
+	for _, section := range sections {
+		if !state.has(section.Category) {
+			continue
+		}
+		addOriginals(originals, state.restore(section.Category, doc))
+		removed = append(removed, section.Category)
+	}
+
+	switch {
+	case len(removed) == 0:
+		return existing, nil, nil
+	case state.created && len(state.categories) == 0 && doc.Len() == 0:
+		return "", removed, nil // aide가 만든 파일은 비면 삭제
+	}
+
+	content, err := g.codec.encode(doc, existing, originals)
+	if err != nil {
+		return "", nil, err
+	}
+	return content, removed, nil
+}
+
+// Classify는 대상 파일에 적용된 카테고리별 상태를 판단합니다
+func (g *MergeGenerator) Classify(target string, sections []Section) ([]SectionStatus, error) {
+	t, err := g.read(target)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := g.classify(t.content, t.state, sections)
+	if err != nil {
+		return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
+	}
+	return statuses, nil
+}
+
+// Plan은 대상 파일에 섹션을 병합한 변경을 계산합니다
+func (g *MergeGenerator) Plan(target string, sections []Section) ([]FileChange, error) {
+	return g.Reconcile(target, sections, nil)
+}
+
+// Applied는 상태 파일에 기록된 카테고리 목록을 반환합니다
+func (g *MergeGenerator) Applied(target string) ([]string, error) {
+	t, err := g.read(target)
+	if err != nil {
+		return nil, err
+	}
+	return t.state.categories, nil
+}
+
+// Reconcile은 stale 카테고리를 병합 전 값으로 되돌리고 sections를 병합한 변경을 계산합니다
+func (g *MergeGenerator) Reconcile(target string, sections, stale []Section) ([]FileChange, error) {
+	t, err := g.read(target)
+	if err != nil {
+		return nil, err
+	}
+
+	content := t.content
+	if len(stale) > 0 {
+		if content, _, err = g.strip(content, t.state, stale); err != nil {
+			return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
+		}
+	}
+	if len(sections) > 0 {
+		if content, err = g.render(content, t.state, sections); err != nil {
+			return nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
+		}
+	}
+	return g.changes(t, content)
+}
+
+// PlanRemoval은 대상 파일에서 섹션의 키를 병합 전 값으로 되돌린 변경을 계산합니다
+func (g *MergeGenerator) PlanRemoval(target string, sections []Section) ([]FileChange, []string, error) {
+	t, err := g.read(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, removed, err := g.strip(t.content, t.state, sections)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", target, err)
+	}
+	if len(removed) == 0 {
+		return nil, nil, nil
+	}
+
+	changes, err := g.changes(t, content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return changes, removed, nil
+}
@@ -0,0 +1,253 @@
+package generators
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	beginMarker = "aide:begin"
+	endMarker   = "aide:end"
+)
+
+// commentStyle은 대상 파일 형식의 주석 문법입니다
+type commentStyle struct {
+	open  string // 주석 시작 (예: "<!--", "#")
+	close string // 주석 끝 (선택사항, 예: "-->")
+}
+
+// markdownStyle은 Markdown 파일에서 사용하는 HTML 주석 형식입니다
+var markdownStyle = commentStyle{open: "<!--", close: "-->"}
+
+// hashStyle은 '#'으로 시작하는 한 줄 주석 형식입니다
+var hashStyle = commentStyle{open: "#"}
+
+// marker는 영역 시작/끝 표시 줄을 생성합니다.
+// attrs는 "이름=값" 형태로 키 뒤에 덧붙입니다.
+func (s commentStyle) marker(kind, key string, attrs ...string) string {
+	line := s.open + " " + kind + " " + key
+	for _, attr := range attrs {
+		line += " " + attr
+	}
+	if s.close != "" {
+		line += " " + s.close
+	}
+	return line
+}
+
+// parseMarker는 한 줄이 영역 표시인지 확인하고 종류와 키, 속성을 반환합니다
+func (s commentStyle) parseMarker(line string) (kind, key string, attrs map[string]string, ok bool) {
+	text := strings.TrimSpace(line)
+	if !strings.HasPrefix(text, s.open) {
+		return "", "", nil, false
+	}
+	text = strings.TrimPrefix(text, s.open)
+	if s.close != "" {
+		if !strings.HasSuffix(text, s.close) {
+			return "", "", nil, false
+		}
+		text = strings.TrimSuffix(text, s.close)
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) < 2 || (fields[0] != beginMarker && fields[0] != endMarker) {
+		return "", "", nil, false
+	}
+
+	attrs = make(map[string]string)
+	for _, field := range fields[2:] {
+		if name, value, found := strings.Cut(field, "="); found {
+			attrs[name] = value
+		}
+	}
+	return fields[0], fields[1], attrs, true
+}
+
+// region은 문서 안에서 aide가 관리하는 영역의 위치입니다
+type region struct {
+	key   string
+	attrs map[string]string // 시작 표시에 기록된 속성
+	start int               // 시작 표시 줄 인덱스
+	end   int               // 끝 표시 줄 인덱스
+}
+
+// document는 aide 관리 영역을 포함한 파일 내용을 줄 단위로 다룹니다
+type document struct {
+	style   commentStyle
+	lines   []string
+	regions []region
+}
+
+// parseDocument는 파일 내용을 읽어 관리 영역을 찾아냅니다
+func parseDocument(content string, style commentStyle) (*document, error) {
+	doc := &document{style: style}
+	if content != "" {
+		doc.lines = strings.Split(strings.TrimRight(content, "\n"), "\n")
+	}
+	if err := doc.scan(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// scan은 줄 목록에서 시작/끝 표시 쌍을 찾아 영역 목록을 다시 만듭니다
+func (d *document) scan() error {
+	d.regions = nil
+	open := -1
+	openKey := ""
+	var openAttrs map[string]string
+
+	for i, line := range d.lines {
+		kind, key, attrs, ok := d.style.parseMarker(line)
+		if !ok {
+			continue
+		}
+
+		switch kind {
+		case beginMarker:
+			if open >= 0 {
+				return fmt.Errorf("aide 영역 '%s'가 닫히지 않았습니다 (%d번째 줄)", openKey, open+1)
+			}
+			if _, exists := d.find(key); exists {
+				return fmt.Errorf("aide 영역 '%s'가 중복되어 있습니다 (%d번째 줄)", key, i+1)
+			}
+			open, openKey, openAttrs = i, key, attrs
+		case endMarker:
+			if open < 0 || key != openKey {
+				return fmt.Errorf("aide 영역 '%s'의 끝 표시가 잘못되었습니다 (%d번째 줄)", key, i+1)
+			}
+			d.regions = append(d.regions, region{key: key, attrs: openAttrs, start: open, end: i})
+			open, openKey = -1, ""
+		}
+	}
+
+	if open >= 0 {
+		return fmt.Errorf("aide 영역 '%s'가 닫히지 않았습니다 (%d번째 줄)", openKey, open+1)
+	}
+	return nil
+}
+
+// find는 키에 해당하는 영역을 찾습니다
+func (d *document) find(key string) (region, bool) {
+	for _, r := range d.regions {
+		if r.key == key {
+			return r, true
+		}
+	}
+	return region{}, false
+}
+
+// keysWithPrefix는 접두사로 시작하는 영역 키를 문서 순서대로 반환합니다
+func (d *document) keysWithPrefix(prefix string) []string {
+	var keys []string
+	for _, r := range d.regions {
+		if strings.HasPrefix(r.key, prefix) {
+			keys = append(keys, r.key)
+		}
+	}
+	return keys
+}
+
+// body는 영역 안쪽 내용을 반환합니다
+func (d *document) body(key string) (string, bool) {
+	r, ok := d.find(key)
+	if !ok {
+		return "", false
+	}
+	return strings.Join(d.lines[r.start+1:r.end], "\n"), true
+}
+
+// attr은 영역 시작 표시에 기록된 속성 값을 반환합니다
+func (d *document) attr(key, name string) string {
+	r, ok := d.find(key)
+	if !ok {
+		return ""
+	}
+	return r.attrs[name]
+}
+
+// block은 표시 줄을 포함한 영역 전체 줄을 만듭니다
+func (d *document) block(key, body string, attrs ...string) []string {
+	lines := []string{d.style.marker(beginMarker, key, attrs...)}
+	if body = strings.Trim(body, "\n"); body != "" {
+		lines = append(lines, strings.Split(body, "\n")...)
+	}
+	return append(lines, d.style.marker(endMarker, key))
+}
+
+// replace는 기존 영역의 내용을 제자리에서 교체합니다
+func (d *document) replace(key, body string, attrs ...string) error {
+	r, ok := d.find(key)
+	if !ok {
+		return fmt.Errorf("aide 영역을 찾을 수 없습니다: %s", key)
+	}
+	d.splice(r.start, r.end+1, d.block(key, body, attrs...))
+	return d.scan()
+}
+
+// insertAfter는 지정한 영역 바로 뒤에 새 영역을 추가합니다.
+// after가 비어 있으면 문서 끝에 추가합니다.
+func (d *document) insertAfter(after, key, body string, attrs ...string) error {
+	block := d.block(key, body, attrs...)
+
+	pos := len(d.lines)
+	if after != "" {
+		r, ok := d.find(after)
+		if !ok {
+			return fmt.Errorf("aide 영역을 찾을 수 없습니다: %s", after)
+		}
+		pos = r.end + 1
+	}
+
+	// 앞쪽 내용과 빈 줄로 구분
+	if pos > 0 && strings.TrimSpace(d.lines[pos-1]) != "" {
+		block = append([]string{""}, block...)
+	}
+	// 뒤쪽 내용이 이어진다면 빈 줄로 구분
+	if pos < len(d.lines) && strings.TrimSpace(d.lines[pos]) != "" {
+		block = append(block, "")
+	}
+
+	d.splice(pos, pos, block)
+	return d.scan()
+}
+
+// remove는 영역과 그 뒤의 빈 줄 하나를 삭제합니다
+func (d *document) remove(key string) (bool, error) {
+	r, ok := d.find(key)
+	if !ok {
+		return false, nil
+	}
+
+	start, end := r.start, r.end+1
+	if end < len(d.lines) && strings.TrimSpace(d.lines[end]) == "" {
+		end++
+	} else if start > 0 && strings.TrimSpace(d.lines[start-1]) == "" {
+		start--
+	}
+
+	d.splice(start, end, nil)
+	return true, d.scan()
+}
+
+// splice는 [start, end) 범위의 줄을 교체합니다
+func (d *document) splice(start, end int, lines []string) {
+	result := make([]string, 0, len(d.lines)-(end-start)+len(lines))
+	result = append(result, d.lines[:start]...)
+	result = append(result, lines...)
+	result = append(result, d.lines[end:]...)
+	d.lines = result
+}
+
+// String은 문서를 파일 내용으로 변환합니다
+func (d *document) String() string {
+	// 끝쪽 빈 줄 정리
+	end := len(d.lines)
+	for end > 0 && strings.TrimSpace(d.lines[end-1]) == "" {
+		end--
+	}
+	if end == 0 {
+		return ""
+	}
+	return strings.Join(d.lines[:end], "\n") + "\n"
+}
@@ -0,0 +1,247 @@
+package generators
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hooneun/aide/internal/storage"
+)
+
+// ruleDirGenerator는 카테고리마다 규칙 파일 하나를 디렉터리에 기록하는 생성기입니다.
+// 규칙 파일 전체를 aide가 관리하며, aide 영역 표시가 없는 파일은 사용자가 만든 것으로 보고 덮어쓰지 않습니다.
+type ruleDirGenerator struct {
+	tool      string       // 영역 키에 사용할 도구 이름
+	style     commentStyle // 영역 표시 줄의 주석 형식
+	pattern   string       // 규칙 파일 이름 패턴 ({category}, {tool} 치환)
+	extension string       // 규칙 파일 확장자 (예: ".mdc")
+
+	// frontMatter는 섹션에서 파일 맨 앞에 쓸 내용과 본문을 분리합니다 (선택사항)
+	frontMatter func(section Section) (header, body string, err error)
+
+	// usesMeta는 frontMatter가 섹션의 메타데이터(설명, globs)를 사용하는지 여부입니다.
+	// 이 경우 메타데이터가 바뀌어도 다시 적용되도록 해시에 메타데이터를 포함합니다.
+	usesMeta bool
+}
+
+// dirGenerator는 동적 도구의 디렉터리 출력 설정으로 생성기를 만듭니다
+func dirGenerator(config *storage.ToolConfig) (*ruleDirGenerator, error) {
+	if config.Format != "" && config.Format != storage.FormatText {
+		return nil, fmt.Errorf("디렉터리 출력은 text 형식만 지원합니다: %s (도구: %s)", config.Format, config.Name)
+	}
+
+	pattern := config.FilePattern
+	if pattern == "" {
+		pattern = storage.DefaultFilePattern
+	}
+	if !strings.Contains(pattern, "{category}") {
+		return nil, fmt.Errorf("파일 이름 패턴에 {category}가 있어야 합니다: %s (도구: %s)", pattern, config.Name)
+	}
+	if strings.Contains(pattern, "..") || filepath.IsAbs(pattern) {
+		return nil, fmt.Errorf("파일 이름 패턴은 대상 디렉터리 안을 가리켜야 합니다: %s (도구: %s)", pattern, config.Name)
+	}
+
+	extension := config.Extension
+	if extension == "" {
+		extension = storage.DefaultExtension
+	}
+	if !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+
+	// 구분자가 있으면 그 주석 형식을, 없으면 Markdown 주석을 사용
+	style := markdownStyle
+	if config.Separator != "" {
+		style = styleFromSeparator(config.Separator)
+	}
+
+	g := &ruleDirGenerator{tool: config.Name, style: style, pattern: pattern, extension: extension}
+	if config.Header != "" {
+		// 규칙 파일마다 맨 앞에 헤더 기록
+		g.frontMatter = func(section Section) (string, string, error) {
+			return config.Header + "\n", section.Prompt, nil
+		}
+	}
+	return g, nil
+}
+
+// path는 카테고리의 규칙 파일 경로를 반환합니다
+func (g *ruleDirGenerator) path(dir, category string) string {
+	name := strings.NewReplacer("{category}", category, "{tool}", g.tool).Replace(g.pattern)
+	return filepath.Join(dir, filepath.FromSlash(name)+g.extension)
+}
+
+// sectionKey는 카테고리 영역의 키를 반환합니다
+func (g *ruleDirGenerator) sectionKey(category string) string {
+	return g.tool + "/" + category
+}
+
+// hash는 규칙 파일에 기록할 섹션의 해시를 반환합니다
+func (g *ruleDirGenerator) hash(section Section) string {
+	if !g.usesMeta || (section.Description == "" && len(section.Globs) == 0) {
+		return HashPrompt(section.Prompt)
+	}
+	return HashPrompt(section.Prompt + "\n" + section.Description + "\n" + strings.Join(section.Globs, ","))
+}
+
+// render는 섹션 하나의 규칙 파일 내용을 만듭니다
+func (g *ruleDirGenerator) render(section Section) (string, error) {
+	header, body := "", section.Prompt
+	if g.frontMatter != nil {
+		var err error
+		if header, body, err = g.frontMatter(section); err != nil {
+			return "", fmt.Errorf("프롬프트 %s의 front matter를 처리할 수 없습니다: %w", g.sectionKey(section.Category), err)
+		}
+	}
+
+	doc, err := parseDocument(header, g.style)
+	if err != nil {
+		return "", err
+	}
+	hash := hashAttr + "=" + g.hash(section)
+	if err := doc.insertAfter("", g.sectionKey(section.Category), body, hash); err != nil {
+		return "", err
+	}
+	return doc.String(), nil
+}
+
+// read는 규칙 파일을 읽고 aide가 관리하는 파일인지 확인합니다
+func (g *ruleDirGenerator) read(dir, category string) (content string, doc *document, exists bool, err error) {
+	filePath := g.path(dir, category)
+	content, exists, err = readTarget(filePath)
+	if err != nil || !exists {
+		return content, nil, exists, err
+	}
+
+	doc, err = parseDocument(content, g.style)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", filePath, err)
+	}
+	if _, ok := doc.find(g.sectionKey(category)); !ok {
+		doc = nil // aide가 관리하지 않는 파일
+	}
+	return content, doc, true, nil
+}
+
+// Classify는 규칙 파일에 기록된 프롬프트 해시와 비교하여 적용 상태를 판단합니다.
+// 기록된 해시가 프롬프트와 같아도 파일 내용이 달라졌으면 직접 수정된 것으로 봅니다.
+func (g *ruleDirGenerator) Classify(dir string, sections []Section) ([]SectionStatus, error) {
+	result := make([]SectionStatus, 0, len(sections))
+	for _, section := range sections {
+		existing, doc, exists, err := g.read(dir, section.Category)
+		if err != nil {
+			return nil, err
+		}
+
+		status := StatusNew
+		if exists {
+			status = StatusChanged
+			if doc != nil && doc.attr(g.sectionKey(section.Category), hashAttr) == g.hash(section) {
+				status = StatusUnchanged
+				if content, err := g.render(section); err == nil && HashPrompt(content) != HashPrompt(existing) {
+					status = StatusModified
+				}
+			}
+		}
+		result = append(result, SectionStatus{Section: section, Status: status})
+	}
+	return result, nil
+}
+
+// Plan은 섹션마다 규칙 파일을 만들거나 교체하는 변경을 계산합니다
+func (g *ruleDirGenerator) Plan(dir string, sections []Section) ([]FileChange, error) {
+	changes := make([]FileChange, 0, len(sections))
+	for _, section := range sections {
+		filePath := g.path(dir, section.Category)
+		existing, doc, exists, err := g.read(dir, section.Category)
+		if err != nil {
+			return nil, err
+		}
+		if exists && doc == nil {
+			return nil, fmt.Errorf("aide가 관리하지 않는 파일이 이미 있습니다: %s", filePath)
+		}
+
+		content, err := g.render(section)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, FileChange{Path: filePath, Before: existing, After: content, Exists: exists})
+	}
+	return changes, nil
+}
+
+// PlanRemoval은 aide가 관리하는 규칙 파일을 삭제하는 변경을 계산합니다
+func (g *ruleDirGenerator) PlanRemoval(dir string, sections []Section) ([]FileChange, []string, error) {
+	var changes []FileChange
+	var removed []string
+	for _, section := range sections {
+		existing, doc, _, err := g.read(dir, section.Category)
+		if err != nil {
+			return nil, nil, err
+		}
+		if doc == nil {
+			continue // 파일이 없거나 aide가 관리하지 않음
+		}
+
+		changes = append(changes, FileChange{
+			Path:   g.path(dir, section.Category),
+			Before: existing,
+			Exists: true,
+			Delete: true,
+			Root:   dir, // 중첩 카테고리의 빈 하위 디렉터리 정리
+		})
+		removed = append(removed, section.Category)
+	}
+	return changes, removed, nil
+}
+
+// Applied는 디렉터리에서 aide가 관리하는 규칙 파일의 카테고리 목록을 반환합니다
+func (g *ruleDirGenerator) Applied(dir string) ([]string, error) {
+	var categories []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir // 디렉터리가 없으면 적용된 규칙 없음
+			}
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(path, g.extension) {
+			return nil
+		}
+
+		content, _, err := readTarget(path)
+		if err != nil {
+			return err
+		}
+		doc, err := parseDocument(content, g.style)
+		if err != nil {
+			return fmt.Errorf("%s을(를) 처리할 수 없습니다: %w", path, err)
+		}
+		for _, key := range doc.keysWithPrefix(g.tool + "/") {
+			category := strings.TrimPrefix(key, g.tool+"/")
+			if g.path(dir, category) == path {
+				categories = append(categories, category)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// Reconcile은 stale 규칙 파일을 삭제하고 sections의 규칙 파일을 만드는 변경을 계산합니다
+func (g *ruleDirGenerator) Reconcile(dir string, sections, stale []Section) ([]FileChange, error) {
+	removal, _, err := g.PlanRemoval(dir, stale)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := g.Plan(dir, sections)
+	if err != nil {
+		return nil, err
+	}
+	return append(removal, changes...), nil
+}
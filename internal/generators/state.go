@@ -0,0 +1,276 @@
+package generators
+
+import (
+	"fmt"
+
+	"github.com/hooneun/aide/internal/tree"
+)
+
+// stateSuffix는 병합 형식 대상 파일 옆에 두는 상태 파일의 접미사입니다 (settings.json.aide-state)
+const stateSuffix = ".aide-state"
+
+// statePath는 대상 파일의 상태 파일 경로를 반환합니다
+func statePath(target string) string {
+	return target + stateSuffix
+}
+
+// mergeEntry는 카테고리를 병합하며 바꾼 키 하나의 기록입니다
+type mergeEntry struct {
+	path     []string // 키 경로
+	existed  bool     // 병합 전에 키가 있었는지 여부
+	previous any      // 병합 전 값 (existed일 때만)
+	source   string   // 병합 전 값의 원본 텍스트 (YAML/TOML, 되돌릴 때 표기를 유지하는 데 사용)
+	value    any      // aide가 병합한 값 (새로 만든 객체는 빈 객체)
+}
+
+// mergeState는 병합 형식 대상 파일에 카테고리별로 무엇을 바꿨는지 기록한 상태입니다.
+// 제거할 때 aide가 바꾼 키만 병합 전 값으로 되돌리는 데 사용합니다.
+type mergeState struct {
+	created    bool                    // aide가 대상 파일을 새로 만들었는지 여부
+	categories []string                // 적용된 카테고리 (적용 순서)
+	entries    map[string][]mergeEntry // 카테고리별 바꾼 키
+}
+
+// newMergeState는 빈 상태를 생성합니다
+func newMergeState() *mergeState {
+	return &mergeState{entries: make(map[string][]mergeEntry)}
+}
+
+// has는 카테고리가 적용된 상태인지 확인합니다
+func (s *mergeState) has(category string) bool {
+	_, ok := s.entries[category]
+	return ok
+}
+
+// record는 prompt를 doc에 병합하기 전에 바뀔 키의 현재 값을 카테고리에 기록합니다.
+// 이미 기록한 키는 병합한 값만 갱신하므로 다시 적용해도 처음 적용하기 전의 값이 유지됩니다.
+// sourceOf가 있으면 병합 전 값의 원본 텍스트도 함께 기록합니다.
+func (s *mergeState) record(category string, doc, prompt *tree.Map, sourceOf func(path []string, value any) string) {
+	if !s.has(category) {
+		s.categories = append(s.categories, category)
+	}
+	entries := recordEntries(s.entries[category], doc, prompt, nil)
+	for i := range entries {
+		if entries[i].existed && entries[i].source == "" && sourceOf != nil {
+			entries[i].source = sourceOf(entries[i].path, entries[i].previous)
+		}
+	}
+	s.entries[category] = entries
+}
+
+// recordEntries는 prompt의 키마다 doc의 현재 값을 기록합니다.
+// 양쪽 모두 객체인 키는 안쪽 키를 기록하고, doc에 없는 객체는 만든 객체와 안쪽 키를 함께 기록합니다.
+func recordEntries(entries []mergeEntry, doc, prompt *tree.Map, path []string) []mergeEntry {
+	for _, key := range prompt.Keys() {
+		keyPath := append(append([]string{}, path...), key)
+		value, _ := prompt.Get(key)
+		current, exists := doc.Get(key)
+
+		if child, ok := value.(*tree.Map); ok {
+			if currentMap, ok := current.(*tree.Map); ok {
+				entries = recordEntries(entries, currentMap, child, keyPath)
+				continue
+			}
+			if !exists {
+				entries = addEntry(entries, mergeEntry{path: keyPath, value: tree.NewMap()})
+				entries = recordEntries(entries, tree.NewMap(), child, keyPath)
+				continue
+			}
+		}
+		entries = addEntry(entries, mergeEntry{path: keyPath, existed: exists, previous: current, value: value})
+	}
+	return entries
+}
+
+// addEntry는 기록을 추가합니다. 같은 경로의 기록이 있으면 병합한 값만 바꿉니다.
+func addEntry(entries []mergeEntry, entry mergeEntry) []mergeEntry {
+	for i := range entries {
+		if samePath(entries[i].path, entry.path) {
+			entries[i].value = entry.value
+			return entries
+		}
+	}
+	return append(entries, entry)
+}
+
+// sameKeys는 카테고리에 기록된 키가 지금 prompt를 병합할 때 기록할 키와 같은지 확인합니다.
+// 새로 만든 객체의 기록은 비교하지 않습니다.
+func (s *mergeState) sameKeys(category string, doc, prompt *tree.Map) bool {
+	leaves := func(entries []mergeEntry) map[string]bool {
+		paths := make(map[string]bool)
+		for _, entry := range entries {
+			if _, created := entry.value.(*tree.Map); created && !entry.existed {
+				continue
+			}
+			paths[fmt.Sprintf("%q", entry.path)] = true
+		}
+		return paths
+	}
+
+	recorded, wanted := leaves(s.entries[category]), leaves(recordEntries(nil, doc, prompt, nil))
+	if len(recorded) != len(wanted) {
+		return false
+	}
+	for path := range wanted {
+		if !recorded[path] {
+			return false
+		}
+	}
+	return true
+}
+
+// restore는 카테고리가 바꾼 키를 병합 전 값으로 되돌리고 상태에서 카테고리를 지웁니다.
+// aide가 병합한 뒤 사용자가 직접 바꾼 키는 건드리지 않습니다.
+// 나중에 적용한 다른 카테고리가 같은 키를 덮어썼다면 문서는 그대로 두고,
+// 그 카테고리가 제거될 때 이 카테고리의 병합 전 값으로 되돌아가도록 기록을 넘겨줍니다.
+// 지운 카테고리의 기록을 반환합니다.
+func (s *mergeState) restore(category string, doc *tree.Map) []mergeEntry {
+	entries := s.entries[category]
+	delete(s.entries, category)
+	for i, name := range s.categories {
+		if name == category {
+			s.categories = append(s.categories[:i], s.categories[i+1:]...)
+			break
+		}
+	}
+
+	// 안쪽 키부터 되돌리도록 기록의 역순으로 처리
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+
+		handedOver := false
+		for _, other := range s.categories {
+			for j := range s.entries[other] {
+				stacked := &s.entries[other][j]
+				if samePath(stacked.path, entry.path) && stacked.existed && tree.Equal(stacked.previous, entry.value) {
+					stacked.existed, stacked.previous, stacked.source = entry.existed, entry.previous, entry.source
+					handedOver = true
+				}
+			}
+		}
+		if handedOver {
+			continue
+		}
+
+		current, ok := tree.Lookup(doc, entry.path)
+		if !ok || !tree.Equal(current, entry.value) {
+			// 새로 만든 객체에 다른 카테고리의 키가 남아 있으면 그 카테고리가 정리하도록 넘김
+			if _, created := entry.value.(*tree.Map); created && !entry.existed {
+				if owner := s.ownerUnder(entry.path); owner != "" {
+					s.entries[owner] = append([]mergeEntry{entry}, s.entries[owner]...)
+				}
+			}
+			continue
+		}
+
+		if entry.existed {
+			tree.SetPath(doc, entry.path, entry.previous)
+		} else {
+			tree.DeletePath(doc, entry.path)
+		}
+	}
+	return entries
+}
+
+// ownerUnder는 경로 아래의 키를 기록한 카테고리를 찾습니다
+func (s *mergeState) ownerUnder(path []string) string {
+	for _, category := range s.categories {
+		for _, entry := range s.entries[category] {
+			if len(entry.path) > len(path) && samePath(entry.path[:len(path)], path) {
+				return category
+			}
+		}
+	}
+	return ""
+}
+
+// samePath는 두 키 경로가 같은지 확인합니다
+func samePath(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// toMap은 상태를 대상 파일과 같은 형식으로 저장할 수 있도록 객체로 변환합니다
+func (s *mergeState) toMap() *tree.Map {
+	categories := tree.NewMap()
+	for _, category := range s.categories {
+		list := make([]any, 0, len(s.entries[category]))
+		for _, entry := range s.entries[category] {
+			path := make([]any, len(entry.path))
+			for i, key := range entry.path {
+				path[i] = key
+			}
+
+			item := tree.NewMap()
+			item.Set("path", path)
+			item.Set("existed", entry.existed)
+			if entry.existed {
+				item.Set("previous", entry.previous)
+			}
+			if entry.source != "" {
+				item.Set("source", entry.source)
+			}
+			item.Set("value", entry.value)
+			list = append(list, item)
+		}
+		categories.Set(category, list)
+	}
+
+	m := tree.NewMap()
+	if s.created {
+		m.Set("created", true)
+	}
+	m.Set("categories", categories)
+	return m
+}
+
+// stateFromMap은 저장된 상태 객체를 해석합니다
+func stateFromMap(m *tree.Map) (*mergeState, error) {
+	s := newMergeState()
+	s.created, _ = valueOf(m, "created").(bool)
+
+	categories, ok := valueOf(m, "categories").(*tree.Map)
+	if !ok {
+		return nil, fmt.Errorf("categories가 없습니다")
+	}
+	for _, category := range categories.Keys() {
+		list, _ := valueOf(categories, category).([]any)
+		entries := make([]mergeEntry, 0, len(list))
+		for _, item := range list {
+			itemMap, ok := item.(*tree.Map)
+			if !ok {
+				return nil, fmt.Errorf("%s의 기록이 객체가 아닙니다", category)
+			}
+			pathList, _ := valueOf(itemMap, "path").([]any)
+			if len(pathList) == 0 {
+				return nil, fmt.Errorf("%s의 기록에 path가 없습니다", category)
+			}
+
+			entry := mergeEntry{path: make([]string, len(pathList))}
+			for i, key := range pathList {
+				entry.path[i] = fmt.Sprint(key)
+			}
+			entry.existed, _ = valueOf(itemMap, "existed").(bool)
+			entry.previous = valueOf(itemMap, "previous")
+			entry.source, _ = valueOf(itemMap, "source").(string)
+			entry.value = valueOf(itemMap, "value")
+			entries = append(entries, entry)
+		}
+		s.categories = append(s.categories, category)
+		s.entries[category] = entries
+	}
+	return s, nil
+}
+
+// valueOf는 객체의 값을 반환합니다 (없으면 nil)
+func valueOf(m *tree.Map, key string) any {
+	value, _ := m.Get(key)
+	return value
+}
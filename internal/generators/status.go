@@ -0,0 +1,49 @@
+package generators
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// hashAttr는 영역 시작 표시에 프롬프트 해시를 기록하는 속성 이름입니다
+const hashAttr = "sha256"
+
+// HashPrompt는 공백 차이를 무시하도록 정규화한 프롬프트의 해시를 반환합니다
+func HashPrompt(prompt string) string {
+	normalized := strings.Join(strings.Fields(prompt), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// Status는 카테고리 하나의 적용 상태입니다
+type Status int
+
+const (
+	StatusNew       Status = iota // 아직 적용되지 않음
+	StatusChanged                 // 적용되었지만 프롬프트 내용이 바뀜
+	StatusUnchanged               // 같은 내용이 이미 적용됨
+	StatusModified                // 프롬프트는 그대로지만 적용된 내용이 직접 수정됨
+)
+
+// String은 상태를 사용자에게 보여줄 문자열로 변환합니다
+func (s Status) String() string {
+	switch s {
+	case StatusNew:
+		return "새로 적용"
+	case StatusChanged:
+		return "변경됨"
+	case StatusUnchanged:
+		return "이미 적용됨"
+	case StatusModified:
+		return "직접 수정됨"
+	default:
+		return "알 수 없음"
+	}
+}
+
+// SectionStatus는 섹션과 그 적용 상태입니다
+type SectionStatus struct {
+	Section
+	Status Status
+}
@@ -0,0 +1,228 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hooneun/aide/internal/render"
+	"github.com/hooneun/aide/internal/tree"
+)
+
+// FileName은 프로젝트 매니페스트 파일 이름입니다
+const FileName = ".aide.yaml"
+
+// ErrNotFound는 매니페스트 파일을 찾지 못했을 때 반환됩니다
+var ErrNotFound = errors.New(FileName + " 파일을 찾을 수 없습니다")
+
+// Entry는 매니페스트에 선언된 도구 하나의 적용 설정입니다
+type Entry struct {
+	Tool       string   // 도구 이름
+	Categories []string // 적용할 카테고리 목록
+	Target     string   // 대상 경로 재정의 (매니페스트 디렉터리 기준, 선택사항)
+}
+
+// Manifest는 프로젝트에 적용할 도구와 카테고리를 선언한 파일입니다
+type Manifest struct {
+	Path  string            // 매니페스트 파일 경로
+	Dir   string            // 프로젝트 디렉터리 (매니페스트가 있는 디렉터리)
+	Tools []Entry           // 선언 순서대로의 도구 목록
+	Vars  map[string]string // 프롬프트 템플릿 변수 (선택사항)
+}
+
+// Find는 startDir부터 상위 디렉터리로 올라가며 매니페스트 파일을 찾습니다
+func Find(startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("경로를 확인할 수 없습니다: %w", err)
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotFound
+		}
+		dir = parent
+	}
+}
+
+// Load는 매니페스트 파일을 읽고 파싱합니다
+func Load(path string) (*Manifest, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("경로를 확인할 수 없습니다: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("매니페스트를 읽을 수 없습니다: %w", err)
+	}
+
+	m, err := parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	m.Path, m.Dir = path, filepath.Dir(path)
+	return m, nil
+}
+
+// TargetPath는 도구의 대상 경로 재정의를 프로젝트 디렉터리 기준 절대 경로로 반환합니다.
+// 재정의가 없으면 빈 문자열을 반환합니다.
+func (m *Manifest) TargetPath(entry Entry) string {
+	if entry.Target == "" {
+		return ""
+	}
+	if filepath.IsAbs(entry.Target) {
+		return entry.Target
+	}
+	return filepath.Join(m.Dir, filepath.FromSlash(entry.Target))
+}
+
+// parse는 매니페스트 내용을 도구 목록과 변수로 변환합니다
+func parse(data string) (*Manifest, error) {
+	doc, err := tree.ParseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	for _, key := range doc.Keys() {
+		switch key {
+		case "tools":
+		case "vars":
+			value, _ := doc.Get(key)
+			if m.Vars, err = parseVars(value); err != nil {
+				return nil, fmt.Errorf("vars: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("알 수 없는 항목입니다: %s", key)
+		}
+	}
+
+	value, ok := doc.Get("tools")
+	if !ok {
+		return nil, fmt.Errorf("tools 항목이 없습니다")
+	}
+	toolsMap, ok := value.(*tree.Map)
+	if !ok {
+		return nil, fmt.Errorf("tools는 도구 이름을 키로 하는 매핑이어야 합니다")
+	}
+
+	for _, name := range toolsMap.Keys() {
+		value, _ := toolsMap.Get(name)
+		entry, err := parseEntry(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("tools.%s: %w", name, err)
+		}
+		m.Tools = append(m.Tools, entry)
+	}
+	return m, nil
+}
+
+// parseVars는 변수 이름을 키로 하는 매핑을 파싱합니다. 숫자와 불리언 값은 문자열로 바꿉니다.
+func parseVars(value any) (map[string]string, error) {
+	varsMap, ok := value.(*tree.Map)
+	if !ok {
+		return nil, fmt.Errorf("변수 이름을 키로 하는 매핑이어야 합니다")
+	}
+
+	vars := make(map[string]string, varsMap.Len())
+	for _, name := range varsMap.Keys() {
+		if err := render.ValidateName(name); err != nil {
+			return nil, err
+		}
+
+		value, _ := varsMap.Get(name)
+		switch v := value.(type) {
+		case nil:
+			vars[name] = ""
+		case string, bool, tree.Number:
+			vars[name] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("%s: 변수 값은 문자열이어야 합니다", name)
+		}
+	}
+	return vars, nil
+}
+
+// parseEntry는 도구 하나의 설정을 파싱합니다.
+// 값은 카테고리 목록, 쉼표로 구분된 문자열 또는 categories/target을 가진 매핑입니다.
+func parseEntry(name string, value any) (Entry, error) {
+	entry := Entry{Tool: name}
+
+	settings, ok := value.(*tree.Map)
+	if !ok {
+		categories, err := parseCategories(value)
+		if err != nil {
+			return entry, err
+		}
+		entry.Categories = categories
+		return entry, nil
+	}
+
+	for _, key := range settings.Keys() {
+		value, _ := settings.Get(key)
+		switch key {
+		case "categories":
+			categories, err := parseCategories(value)
+			if err != nil {
+				return entry, err
+			}
+			entry.Categories = categories
+		case "target":
+			target, ok := value.(string)
+			if !ok || strings.TrimSpace(target) == "" {
+				return entry, fmt.Errorf("target은 비어 있지 않은 문자열이어야 합니다")
+			}
+			entry.Target = target
+		default:
+			return entry, fmt.Errorf("알 수 없는 항목입니다: %s", key)
+		}
+	}
+
+	if len(entry.Categories) == 0 {
+		return entry, fmt.Errorf("categories가 없습니다")
+	}
+	return entry, nil
+}
+
+// parseCategories는 카테고리 목록 또는 쉼표로 구분된 문자열을 파싱합니다
+func parseCategories(value any) ([]string, error) {
+	var raw []string
+	switch v := value.(type) {
+	case string:
+		raw = strings.Split(v, ",")
+	case []any:
+		for _, item := range v {
+			category, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("카테고리는 문자열이어야 합니다: %v", item)
+			}
+			raw = append(raw, category)
+		}
+	default:
+		return nil, fmt.Errorf("카테고리 목록이어야 합니다")
+	}
+
+	var categories []string
+	for _, category := range raw {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
+		}
+	}
+	if len(categories) == 0 {
+		return nil, fmt.Errorf("카테고리가 비어 있습니다")
+	}
+	return categories, nil
+}
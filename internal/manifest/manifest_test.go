@@ -0,0 +1,86 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	content := `# 팀 AI 설정
+tools:
+  claude: [review, backend]
+  cursor:
+    categories:
+      - backend
+    target: docs/.cursorrules
+  agents: "go, testing"
+vars:
+  team: platform
+  retries: 3
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, FileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 하위 디렉터리에서도 찾아야 함
+	subDir := filepath.Join(tmpDir, "internal", "pkg")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path, err := Find(subDir)
+	if err != nil {
+		t.Fatalf("매니페스트를 찾지 못했습니다: %v", err)
+	}
+
+	m, err := Load(path)
+	if err != nil {
+		t.Fatalf("매니페스트 파싱 실패: %v", err)
+	}
+
+	if len(m.Tools) != 3 {
+		t.Fatalf("도구 개수가 일치하지 않습니다: %+v", m.Tools)
+	}
+	if m.Tools[0].Tool != "claude" || len(m.Tools[0].Categories) != 2 {
+		t.Errorf("claude 설정이 일치하지 않습니다: %+v", m.Tools[0])
+	}
+	if target := m.TargetPath(m.Tools[1]); target != filepath.Join(m.Dir, "docs", ".cursorrules") {
+		t.Errorf("대상 경로 재정의가 일치하지 않습니다: %s", target)
+	}
+	if m.TargetPath(m.Tools[0]) != "" {
+		t.Error("재정의가 없으면 빈 경로여야 합니다")
+	}
+	if cats := m.Tools[2].Categories; len(cats) != 2 || cats[1] != "testing" {
+		t.Errorf("쉼표로 구분된 카테고리가 일치하지 않습니다: %v", cats)
+	}
+	if m.Vars["team"] != "platform" || m.Vars["retries"] != "3" {
+		t.Errorf("변수가 일치하지 않습니다: %v", m.Vars)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"tools 없음":     "version: 1\n",
+		"카테고리 없음":      "tools:\n  claude:\n    target: CLAUDE.md\n",
+		"알 수 없는 도구 설정": "tools:\n  claude:\n    categoris: [review]\n",
+		"잘못된 변수 이름":    "tools:\n  claude: [review]\nvars:\n  my-team: platform\n",
+		"목록 변수 값":      "tools:\n  claude: [review]\nvars:\n  teams: [a, b]\n",
+	}
+	for name, content := range tests {
+		if _, err := parse(content); err == nil {
+			t.Errorf("%s: 오류를 반환해야 합니다", name)
+		}
+	}
+
+	if _, err := Find(t.TempDir()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("매니페스트가 없으면 ErrNotFound를 반환해야 합니다: %v", err)
+	}
+}
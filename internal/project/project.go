@@ -0,0 +1,231 @@
+package project
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxScannedFiles는 주 언어를 판단할 때 살펴볼 최대 파일 수입니다
+const maxScannedFiles = 10000
+
+// languages는 파일 확장자별 언어 이름입니다
+var languages = map[string]string{
+	".go":    "Go",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+	".js":    "JavaScript",
+	".jsx":   "JavaScript",
+	".mjs":   "JavaScript",
+	".py":    "Python",
+	".rs":    "Rust",
+	".java":  "Java",
+	".kt":    "Kotlin",
+	".rb":    "Ruby",
+	".php":   "PHP",
+	".cs":    "C#",
+	".c":     "C",
+	".h":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".hpp":   "C++",
+	".swift": "Swift",
+	".scala": "Scala",
+	".dart":  "Dart",
+	".ex":    "Elixir",
+	".exs":   "Elixir",
+}
+
+// skippedDirs는 주 언어를 판단할 때 건너뛸 디렉터리입니다 (숨김 디렉터리도 건너뜀)
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+}
+
+// Facts는 프로젝트 디렉터리에서 자동으로 찾은 정보입니다. 찾지 못한 항목은 비어 있습니다.
+type Facts struct {
+	Module   string // go.mod의 모듈 경로
+	Repo     string // 저장소 이름 (git origin 원격 URL 또는 저장소 디렉터리 이름)
+	Language string // 파일 수가 가장 많은 언어
+}
+
+// Detect는 프로젝트 디렉터리의 정보를 찾습니다.
+// go.mod와 저장소 루트는 dir부터 상위로 올라가며 찾되, 저장소 루트 바깥은 보지 않습니다.
+func Detect(dir string) Facts {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return Facts{}
+	}
+
+	root := repoRoot(dir)
+	top := root
+	if top == "" {
+		top = dir
+	}
+
+	return Facts{
+		Module:   findModule(dir, top),
+		Repo:     repoName(root, top),
+		Language: primaryLanguage(top),
+	}
+}
+
+// VarNames는 Facts가 제공하는 템플릿 변수 이름입니다
+var VarNames = []string{"Module", "Repo", "Language"}
+
+// Vars는 찾은 정보를 템플릿 변수로 반환합니다 (Module, Repo, Language, 빈 항목 제외)
+func (f Facts) Vars() map[string]string {
+	vars := make(map[string]string)
+	for name, value := range map[string]string{"Module": f.Module, "Repo": f.Repo, "Language": f.Language} {
+		if value != "" {
+			vars[name] = value
+		}
+	}
+	return vars
+}
+
+// repoRoot는 dir부터 상위로 올라가며 .git이 있는 디렉터리를 찾습니다. 없으면 빈 문자열을 반환합니다.
+func repoRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// findModule은 dir부터 top까지 올라가며 찾은 go.mod의 모듈 경로를 반환합니다
+func findModule(dir, top string) string {
+	for {
+		if module := readModule(filepath.Join(dir, "go.mod")); module != "" {
+			return module
+		}
+		if dir == top {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// readModule은 go.mod 파일의 module 지시어를 읽습니다
+func readModule(goMod string) string {
+	file, err := os.Open(goMod)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		rest, ok := strings.CutPrefix(line, "module")
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		if i := strings.Index(rest, "//"); i >= 0 {
+			rest = rest[:i]
+		}
+		module := strings.TrimSpace(rest)
+		if unquoted, err := strconv.Unquote(module); err == nil {
+			module = unquoted
+		}
+		return module
+	}
+	return ""
+}
+
+// repoName은 git origin 원격 URL의 마지막 경로에서 저장소 이름을 찾고, 없으면 디렉터리 이름을 사용합니다
+func repoName(root, top string) string {
+	if root != "" {
+		if url := originURL(filepath.Join(root, ".git", "config")); url != "" {
+			url = strings.TrimSuffix(strings.TrimRight(url, "/"), ".git")
+			if i := strings.LastIndexAny(url, "/:"); i >= 0 {
+				url = url[i+1:]
+			}
+			if url != "" {
+				return url
+			}
+		}
+	}
+	return filepath.Base(top)
+}
+
+// originURL은 git 설정 파일에서 origin 원격의 URL을 읽습니다
+func originURL(gitConfig string) string {
+	file, err := os.Open(gitConfig)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	inOrigin := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inOrigin = line == `[remote "origin"]`
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); inOrigin && ok && strings.TrimSpace(key) == "url" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// primaryLanguage는 디렉터리에서 파일 수가 가장 많은 언어를 반환합니다 (같으면 이름순)
+func primaryLanguage(dir string) string {
+	counts := make(map[string]int)
+	scanned := 0
+	filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // 읽을 수 없는 항목은 건너뜀
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if p != dir && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if scanned++; scanned > maxScannedFiles {
+			return filepath.SkipAll
+		}
+		if language, ok := languages[strings.ToLower(path.Ext(entry.Name()))]; ok {
+			counts[language]++
+		}
+		return nil
+	})
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
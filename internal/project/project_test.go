@@ -0,0 +1,63 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "checkout")
+
+	files := map[string]string{
+		".git/config":            "[core]\n\tbare = false\n[remote \"origin\"]\n\turl = git@github.com:acme/payments-api.git\n",
+		"go.mod":                 "// 주석\nmodule github.com/acme/payments-api // 모듈\n\ngo 1.24\n",
+		"main.go":                "package main",
+		"internal/server/a.go":   "package server",
+		"web/app.ts":             "",
+		"node_modules/x/a.js":    "",
+		"node_modules/x/b.js":    "",
+		"node_modules/x/c.js":    "",
+		".cache/generated/z.py":  "",
+		"internal/server/README": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 하위 디렉터리에서도 저장소 루트 기준으로 찾아야 함
+	facts := Detect(filepath.Join(root, "internal", "server"))
+	expected := Facts{Module: "github.com/acme/payments-api", Repo: "payments-api", Language: "Go"}
+	if facts != expected {
+		t.Errorf("프로젝트 정보가 일치하지 않습니다.\n예상: %+v\n실제: %+v", expected, facts)
+	}
+
+	vars := facts.Vars()
+	if vars["Module"] != expected.Module || vars["Repo"] != expected.Repo || vars["Language"] != "Go" {
+		t.Errorf("템플릿 변수가 일치하지 않습니다: %v", vars)
+	}
+}
+
+func TestDetect_WithoutRepo(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "scratch")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	facts := Detect(dir)
+	if facts.Module != "" || facts.Language != "" {
+		t.Errorf("찾을 정보가 없어야 합니다: %+v", facts)
+	}
+	if facts.Repo != "scratch" {
+		t.Errorf("저장소가 없으면 디렉터리 이름을 사용해야 합니다: %s", facts.Repo)
+	}
+	if _, ok := facts.Vars()["Module"]; ok {
+		t.Error("빈 항목은 변수에서 빠져야 합니다")
+	}
+}
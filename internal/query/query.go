@@ -0,0 +1,300 @@
+package query
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hooneun/aide/internal/storage"
+)
+
+// Expr는 프롬프트를 선택하는 쿼리 식입니다
+type Expr interface {
+	// Match는 카테고리와 메타데이터가 식과 일치하는지 확인합니다
+	Match(category string, meta storage.PromptMeta) bool
+	String() string
+}
+
+// tagExpr는 태그가 있는 프롬프트와 일치합니다 (대소문자 구분 없음)
+type tagExpr string
+
+func (e tagExpr) Match(_ string, meta storage.PromptMeta) bool { return meta.HasTag(string(e)) }
+func (e tagExpr) String() string                               { return string(e) }
+
+// categoryExpr는 카테고리 패턴과 일치하는 프롬프트와 일치합니다.
+// 패턴은 path.Match 형식이며, 패턴과 같은 이름의 상위 카테고리 아래에 있는 카테고리도 일치합니다.
+type categoryExpr string
+
+func (e categoryExpr) Match(category string, _ storage.PromptMeta) bool {
+	pattern := string(e)
+	if ok, _ := path.Match(pattern, category); ok {
+		return true
+	}
+	return strings.HasPrefix(category, pattern+"/")
+}
+func (e categoryExpr) String() string { return "category:" + string(e) }
+
+// descExpr는 설명에 단어가 포함된 프롬프트와 일치합니다 (대소문자 구분 없음)
+type descExpr string
+
+func (e descExpr) Match(_ string, meta storage.PromptMeta) bool {
+	return strings.Contains(strings.ToLower(meta.Description), strings.ToLower(string(e)))
+}
+func (e descExpr) String() string { return "desc:" + string(e) }
+
+// notExpr는 식과 일치하지 않는 프롬프트와 일치합니다
+type notExpr struct{ expr Expr }
+
+func (e notExpr) Match(category string, meta storage.PromptMeta) bool {
+	return !e.expr.Match(category, meta)
+}
+func (e notExpr) String() string { return "not " + e.expr.String() }
+
+// andExpr는 모든 식과 일치하는 프롬프트와 일치합니다
+type andExpr []Expr
+
+func (e andExpr) Match(category string, meta storage.PromptMeta) bool {
+	for _, expr := range e {
+		if !expr.Match(category, meta) {
+			return false
+		}
+	}
+	return true
+}
+func (e andExpr) String() string { return join([]Expr(e), " and ") }
+
+// orExpr는 식 중 하나와 일치하는 프롬프트와 일치합니다
+type orExpr []Expr
+
+func (e orExpr) Match(category string, meta storage.PromptMeta) bool {
+	for _, expr := range e {
+		if expr.Match(category, meta) {
+			return true
+		}
+	}
+	return false
+}
+func (e orExpr) String() string { return join([]Expr(e), " or ") }
+
+// join은 하위 식을 연결합니다. and/or 식은 괄호로 묶습니다.
+func join(exprs []Expr, sep string) string {
+	parts := make([]string, len(exprs))
+	for i, expr := range exprs {
+		parts[i] = expr.String()
+		switch expr.(type) {
+		case andExpr, orExpr:
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// AnyTag는 태그 중 하나라도 있는 프롬프트와 일치하는 식을 만듭니다
+func AnyTag(tags []string) Expr {
+	tags = storage.NormalizeTags(tags)
+	if len(tags) == 1 {
+		return tagExpr(tags[0])
+	}
+	exprs := make(orExpr, len(tags))
+	for i, tag := range tags {
+		exprs[i] = tagExpr(tag)
+	}
+	return exprs
+}
+
+// All은 모든 식과 일치하는 프롬프트와 일치하는 식을 만듭니다 (nil은 무시)
+func All(exprs ...Expr) Expr {
+	var result andExpr
+	for _, expr := range exprs {
+		if expr != nil {
+			result = append(result, expr)
+		}
+	}
+	switch len(result) {
+	case 0:
+		return nil
+	case 1:
+		return result[0]
+	}
+	return result
+}
+
+// Parse는 쿼리 문자열을 해석합니다.
+//
+// 문법:
+//
+//	go                  go 태그가 있는 프롬프트
+//	category:go/*       카테고리 패턴 (cat:도 가능)
+//	desc:보안           설명에 단어가 포함된 프롬프트
+//	a and b, a b        둘 다 일치
+//	a or b              하나라도 일치
+//	not a               일치하지 않음
+//	( ... )             묶음
+//
+// 우선순위는 not, and, or 순서이며 키워드는 대소문자를 구분하지 않습니다.
+func Parse(input string) (Expr, error) {
+	p := &parser{tokens: tokenize(input)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("쿼리가 비어 있습니다")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("쿼리를 해석할 수 없습니다: %w", err)
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("쿼리를 해석할 수 없습니다: 예상하지 못한 %q", p.peek())
+	}
+	return expr, nil
+}
+
+// tokenize는 쿼리를 공백과 괄호 기준으로 나눕니다
+func tokenize(input string) []string {
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range input {
+		switch {
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// parser는 토큰 목록을 식으로 만드는 재귀 하강 파서입니다
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// keyword는 다음 토큰이 키워드이면 소비합니다
+func (p *parser) keyword(word string) bool {
+	if !p.done() && strings.EqualFold(p.peek(), word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseOr는 or로 연결된 식을 해석합니다
+func (p *parser) parseOr() (Expr, error) {
+	var exprs orExpr
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if !p.keyword("or") {
+			break
+		}
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+// parseAnd는 and로 (또는 공백으로) 연결된 식을 해석합니다
+func (p *parser) parseAnd() (Expr, error) {
+	var exprs andExpr
+	for {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+
+		if p.keyword("and") {
+			continue
+		}
+		// 키워드 없이 이어지는 항은 and로 연결
+		if p.done() || p.peek() == ")" || strings.EqualFold(p.peek(), "or") {
+			break
+		}
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+// parseNot은 not이 붙은 항을 해석합니다
+func (p *parser) parseNot() (Expr, error) {
+	if p.keyword("not") {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr}, nil
+	}
+	return p.parseTerm()
+}
+
+// parseTerm은 괄호로 묶인 식이나 태그, 카테고리, 설명 조건 하나를 해석합니다
+func (p *parser) parseTerm() (Expr, error) {
+	if p.done() {
+		return nil, fmt.Errorf("식이 끝나지 않았습니다")
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case token == "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("닫는 괄호가 없습니다")
+		}
+		p.pos++
+		return expr, nil
+	case token == ")":
+		return nil, fmt.Errorf("여는 괄호 없이 닫는 괄호가 있습니다")
+	case strings.EqualFold(token, "and") || strings.EqualFold(token, "or"):
+		return nil, fmt.Errorf("%s 앞에 조건이 없습니다", token)
+	}
+
+	key, value, found := strings.Cut(token, ":")
+	if !found {
+		return tagExpr(token), nil
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%s 조건에 값이 없습니다", token)
+	}
+	switch strings.ToLower(key) {
+	case "tag":
+		return tagExpr(value), nil
+	case "category", "cat":
+		if _, err := path.Match(value, ""); err != nil {
+			return nil, fmt.Errorf("잘못된 카테고리 패턴입니다: %s", value)
+		}
+		return categoryExpr(value), nil
+	case "desc":
+		return descExpr(value), nil
+	}
+	return nil, fmt.Errorf("알 수 없는 조건입니다: %s (tag, category, desc 중 하나를 사용하세요)", key)
+}
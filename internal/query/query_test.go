@@ -0,0 +1,80 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/hooneun/aide/internal/storage"
+)
+
+func TestParse_Match(t *testing.T) {
+	prompts := map[string]storage.PromptMeta{
+		"backend":   {Tags: []string{"Go", "backend"}, Description: "Go 백엔드 규칙"},
+		"go/errors": {Tags: []string{"go"}},
+		"security":  {Tags: []string{"security"}, Description: "보안 점검"},
+		"frontend":  {Tags: []string{"ts"}},
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"go", []string{"backend", "go/errors"}},
+		{"go and backend", []string{"backend"}},
+		{"go backend", []string{"backend"}}, // 공백은 and
+		{"go or security", []string{"backend", "go/errors", "security"}},
+		{"go and not backend", []string{"go/errors"}},
+		{"(go or ts) and not category:go/*", []string{"backend", "frontend"}},
+		{"category:go", []string{"go/errors"}}, // 상위 카테고리 아래도 일치
+		{"desc:보안 OR tag:TS", []string{"frontend", "security"}},
+		{"not (go or security)", []string{"frontend"}},
+	}
+
+	for _, tt := range tests {
+		expr, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("%q 해석 실패: %v", tt.query, err)
+			continue
+		}
+
+		var matched []string
+		for _, category := range []string{"backend", "frontend", "go/errors", "security"} {
+			if expr.Match(category, prompts[category]) {
+				matched = append(matched, category)
+			}
+		}
+		if len(matched) != len(tt.expected) {
+			t.Errorf("%q: 예상 %v, 실제 %v", tt.query, tt.expected, matched)
+			continue
+		}
+		for i := range matched {
+			if matched[i] != tt.expected[i] {
+				t.Errorf("%q: 예상 %v, 실제 %v", tt.query, tt.expected, matched)
+				break
+			}
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, input := range []string{"", "(go", "go)", "and go", "go or", "not", "owner:me", "tag:", "category:[a"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("%q는 오류를 반환해야 합니다", input)
+		}
+	}
+}
+
+func TestAnyTagAndAll(t *testing.T) {
+	expr := All(AnyTag([]string{"go", " security", "GO"}), nil, notExpr{tagExpr("legacy")})
+	if got := expr.String(); got != "(go or security) and not legacy" {
+		t.Errorf("식이 일치하지 않습니다: %s", got)
+	}
+	if !expr.Match("review", storage.PromptMeta{Tags: []string{"security"}}) {
+		t.Error("security 태그가 있는 프롬프트와 일치해야 합니다")
+	}
+	if expr.Match("review", storage.PromptMeta{Tags: []string{"go", "legacy"}}) {
+		t.Error("legacy 태그가 있는 프롬프트와 일치하지 않아야 합니다")
+	}
+	if All(nil, nil) != nil {
+		t.Error("빈 식은 nil이어야 합니다")
+	}
+}
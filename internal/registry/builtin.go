@@ -0,0 +1,81 @@
+package registry
+
+import "github.com/hooneun/aide/internal/storage"
+
+// markdownSeparator는 Markdown 파일에서 aide 영역을 HTML 주석으로 표시하게 하는 구분자입니다
+const markdownSeparator = "<!-- --- -->"
+
+// builtinTools는 aide가 기본으로 제공하는 도구 목록입니다.
+// 같은 이름의 사용자 설정(~/.aide/tools/<이름>.json)이 있으면 사용자 설정이 우선합니다.
+var builtinTools = []Tool{
+	{
+		Kind: KindClaude,
+		ToolConfig: storage.ToolConfig{
+			Name:        "claude",
+			FileName:    "CLAUDE.md",
+			Description: "Claude Code 프로젝트 지침",
+		},
+	},
+	{
+		Kind: KindCursor,
+		ToolConfig: storage.ToolConfig{
+			Name:        "cursor",
+			FileName:    ".cursorrules",
+			Description: "Cursor 규칙 (aide migrate-cursor로 .cursor/rules/.aide-rules를 만들면 카테고리별 .mdc 파일)",
+		},
+	},
+	{
+		Kind: KindText,
+		ToolConfig: storage.ToolConfig{
+			Name:        "agents",
+			FileName:    "AGENTS.md",
+			Description: "AGENTS.md 에이전트 지침 (Codex, Jules 등)",
+			Separator:   markdownSeparator,
+		},
+	},
+	{
+		Kind: KindText,
+		ToolConfig: storage.ToolConfig{
+			Name:        "gemini",
+			FileName:    "GEMINI.md",
+			Description: "Gemini CLI 컨텍스트 파일",
+			Separator:   markdownSeparator,
+		},
+	},
+	{
+		Kind: KindText,
+		ToolConfig: storage.ToolConfig{
+			Name:        "copilot",
+			FileName:    ".github/copilot-instructions.md",
+			Description: "GitHub Copilot 사용자 지정 지침",
+			Separator:   markdownSeparator,
+		},
+	},
+	{
+		Kind: KindText,
+		ToolConfig: storage.ToolConfig{
+			Name:        "windsurf",
+			FileName:    ".windsurfrules",
+			Description: "Windsurf 규칙 파일",
+			Separator:   markdownSeparator,
+		},
+	},
+	{
+		Kind: KindText,
+		ToolConfig: storage.ToolConfig{
+			Name:        "cline",
+			FileName:    ".clinerules",
+			Description: "Cline 규칙 파일",
+			Separator:   markdownSeparator,
+		},
+	},
+	{
+		Kind: KindText,
+		ToolConfig: storage.ToolConfig{
+			Name:        "aider",
+			FileName:    "CONVENTIONS.md",
+			Description: "aider 코딩 컨벤션 (.aider.conf.yml의 read에 추가하여 사용)",
+			Separator:   markdownSeparator,
+		},
+	},
+}
@@ -0,0 +1,134 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hooneun/aide/internal/storage"
+)
+
+// Kind는 도구 파일을 만드는 생성기 종류입니다
+type Kind string
+
+const (
+	KindClaude    Kind = "claude"    // CLAUDE.md의 aide 영역
+	KindCursor    Kind = "cursor"    // .cursorrules의 aide 영역 또는 .cursor/rules/*.mdc 규칙 파일
+	KindText      Kind = "text"      // 구분자 주석 형식을 따르는 aide 영역
+	KindDirectory Kind = "directory" // 카테고리마다 규칙 파일 하나
+	KindJSON      Kind = "json"      // JSON 깊은 병합
+	KindYAML      Kind = "yaml"      // YAML 깊은 병합
+	KindTOML      Kind = "toml"      // TOML 깊은 병합
+)
+
+// CursorRulesDir는 프로젝트 기준 Cursor 규칙 디렉터리 경로입니다
+const CursorRulesDir = ".cursor/rules"
+
+// CursorRulesMarker는 프로젝트가 .cursorrules 대신 규칙 디렉터리의 .mdc 파일을 쓰도록
+// 전환했음을 기록하는 파일입니다. 'aide migrate-cursor'가 규칙 디렉터리 안에 만듭니다.
+const CursorRulesMarker = ".aide-rules"
+
+// UsesCursorRules는 프로젝트가 규칙 디렉터리의 .mdc 파일을 쓰도록 전환했는지 확인합니다
+func UsesCursorRules(projectDir string) bool {
+	info, err := os.Stat(filepath.Join(projectDir, CursorRulesDir, CursorRulesMarker))
+	return err == nil && !info.IsDir()
+}
+
+// Tool은 레지스트리에 등록된 도구 정의입니다
+type Tool struct {
+	storage.ToolConfig
+	Kind       Kind // 생성기 종류
+	Builtin    bool // 기본 제공 도구 여부
+	Overridden bool // 기본 제공 도구를 사용자 설정이 재정의했는지 여부
+}
+
+// Registry는 기본 제공 도구와 사용자가 추가한 도구를 하나로 모은 목록입니다
+type Registry struct {
+	tools map[string]*Tool
+	order []string // 기본 제공 도구 순서, 이어서 사용자 도구 이름순
+}
+
+// New는 기본 제공 도구와 저장소의 사용자 도구 설정으로 레지스트리를 만듭니다.
+// 같은 이름의 사용자 설정이 있으면 기본 제공 도구를 재정의합니다.
+func New(store *storage.Storage) (*Registry, error) {
+	r := &Registry{tools: make(map[string]*Tool)}
+	for _, tool := range builtinTools {
+		tool := tool
+		tool.Builtin = true
+		r.add(&tool)
+	}
+
+	configs, err := store.ListToolConfigs()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+
+	for _, config := range configs {
+		tool := &Tool{ToolConfig: config, Kind: kindOf(config)}
+		if builtin, ok := r.tools[config.Name]; ok && builtin.Builtin {
+			tool.Builtin = true
+			tool.Overridden = true
+		}
+		r.add(tool)
+	}
+	return r, nil
+}
+
+// add는 도구를 등록합니다. 이미 있는 이름이면 순서를 유지한 채 교체합니다.
+func (r *Registry) add(tool *Tool) {
+	if _, ok := r.tools[tool.Name]; !ok {
+		r.order = append(r.order, tool.Name)
+	}
+	r.tools[tool.Name] = tool
+}
+
+// Get은 이름에 해당하는 도구를 반환합니다
+func (r *Registry) Get(name string) (*Tool, error) {
+	tool, ok := r.tools[name]
+	if !ok {
+		return nil, fmt.Errorf("지원되지 않는 도구입니다: %s ('aide list-tools'로 사용 가능한 도구 확인, 'aide add-tool'로 추가)", name)
+	}
+	return tool, nil
+}
+
+// Tools는 등록된 모든 도구를 순서대로 반환합니다
+func (r *Registry) Tools() []*Tool {
+	tools := make([]*Tool, 0, len(r.order))
+	for _, name := range r.order {
+		tools = append(tools, r.tools[name])
+	}
+	return tools
+}
+
+// Target은 프로젝트 디렉터리 기준 도구의 대상 경로를 반환합니다.
+// 디렉터리 출력 도구와 규칙 디렉터리를 쓰도록 전환한 Cursor는 디렉터리 경로를 반환합니다.
+func (t *Tool) Target(projectDir string) string {
+	if t.Kind == KindCursor && UsesCursorRules(projectDir) {
+		// migrate-cursor로 전환한 프로젝트는 카테고리별 .mdc 규칙 파일 사용
+		return filepath.Join(projectDir, CursorRulesDir)
+	}
+	return filepath.Join(projectDir, t.FileName)
+}
+
+// DisplayTarget은 도구의 대상 경로를 표시용으로 반환합니다
+func (t *Tool) DisplayTarget() string {
+	if t.Kind == KindDirectory {
+		return t.FileName + "/" // 카테고리마다 파일을 만드는 디렉터리
+	}
+	return t.FileName
+}
+
+// kindOf는 사용자 도구 설정의 출력 방식과 파일 형식으로 생성기 종류를 정합니다
+func kindOf(config storage.ToolConfig) Kind {
+	if config.IsDirectory() {
+		return KindDirectory
+	}
+	switch config.Format {
+	case "", storage.FormatText:
+		return KindText
+	default:
+		return Kind(config.Format) // 지원하지 않는 형식은 생성기를 만들 때 오류
+	}
+}
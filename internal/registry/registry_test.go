@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hooneun/aide/internal/storage"
+)
+
+func TestRegistry_UserConfigOverridesBuiltin(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := storage.New(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 기본 제공 도구 재정의와 새 도구 추가
+	configs := []storage.ToolConfig{
+		{Name: "agents", FileName: "docs/AGENTS.md", Description: "사용자 설정"},
+		{Name: "vscode", FileName: ".vscode/settings.json", Description: "VS Code", Format: storage.FormatJSON},
+		{Name: "rules", FileName: ".rules", Description: "규칙 디렉터리", Output: storage.OutputDirectory},
+	}
+	for _, config := range configs {
+		if err := store.SaveToolConfig(config); err != nil {
+			t.Fatalf("도구 설정 저장 실패: %v", err)
+		}
+	}
+
+	reg, err := New(store)
+	if err != nil {
+		t.Fatalf("레지스트리 생성 실패: %v", err)
+	}
+
+	agents, err := reg.Get("agents")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agents.FileName != "docs/AGENTS.md" || !agents.Overridden || agents.Kind != KindText {
+		t.Errorf("사용자 설정이 기본 제공 도구를 재정의해야 합니다: %+v", agents)
+	}
+
+	kinds := map[string]Kind{"claude": KindClaude, "cursor": KindCursor, "vscode": KindJSON, "rules": KindDirectory}
+	for name, kind := range kinds {
+		tool, err := reg.Get(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tool.Kind != kind {
+			t.Errorf("%s의 생성기 종류가 일치하지 않습니다. 예상: %s, 실제: %s", name, kind, tool.Kind)
+		}
+	}
+
+	// 기본 제공 도구가 먼저, 사용자 도구는 이름순
+	tools := reg.Tools()
+	if tools[0].Name != "claude" || tools[len(tools)-1].Name != "vscode" {
+		t.Errorf("도구 순서가 일치하지 않습니다: %s ... %s", tools[0].Name, tools[len(tools)-1].Name)
+	}
+
+	if _, err := reg.Get("unknown"); err == nil {
+		t.Error("없는 도구는 오류를 반환해야 합니다")
+	}
+}
+
+func TestTool_CursorTargetFollowsRulesMarker(t *testing.T) {
+	// 임시 디렉터리 생성
+	projectDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(projectDir)
+
+	cursor := &builtinTools[1]
+	if target := cursor.Target(projectDir); target != filepath.Join(projectDir, ".cursorrules") {
+		t.Errorf("규칙 디렉터리가 없으면 .cursorrules를 사용해야 합니다: %s", target)
+	}
+
+	rulesDir := filepath.Join(projectDir, CursorRulesDir)
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if target := cursor.Target(projectDir); target != filepath.Join(projectDir, ".cursorrules") {
+		t.Errorf("전환을 기록하지 않았으면 규칙 디렉터리가 있어도 .cursorrules를 사용해야 합니다: %s", target)
+	}
+
+	if err := os.WriteFile(filepath.Join(rulesDir, CursorRulesMarker), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if target := cursor.Target(projectDir); target != rulesDir {
+		t.Errorf("전환을 기록했으면 규칙 디렉터리를 사용해야 합니다: %s", target)
+	}
+}
@@ -0,0 +1,151 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// namePattern은 템플릿 변수 이름 형식입니다 ({{.이름}}으로 참조할 수 있어야 함)
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateName은 템플릿 변수 이름이 올바른지 확인합니다
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("잘못된 변수 이름입니다: %q (문자, 숫자, _만 사용할 수 있고 숫자로 시작할 수 없습니다)", name)
+	}
+	return nil
+}
+
+// UndefinedError는 템플릿이 정의되지 않은 변수를 참조할 때 반환됩니다
+type UndefinedError struct {
+	Names []string // 정의되지 않은 변수 이름 (정렬됨)
+}
+
+func (e *UndefinedError) Error() string {
+	return "정의되지 않은 템플릿 변수가 있습니다: " + strings.Join(e.Names, ", ")
+}
+
+// IsTemplate은 텍스트에 템플릿 동작({{ ... }})이 있는지 확인합니다
+func IsTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+// Render는 텍스트를 text/template으로 렌더링합니다. 변수는 {{.이름}}으로 참조합니다.
+// 템플릿 동작이 없으면 텍스트를 그대로 반환하고, 정의되지 않은 변수를 참조하면
+// <no value>를 출력하는 대신 참조한 변수를 모두 나열한 UndefinedError를 반환합니다.
+func Render(name, text string, vars map[string]string) (string, error) {
+	if !IsTemplate(text) {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("템플릿을 해석할 수 없습니다: %w", err)
+	}
+
+	if missing := undefined(tmpl, vars); len(missing) > 0 {
+		return "", &UndefinedError{Names: missing}
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, vars); err != nil {
+		return "", fmt.Errorf("템플릿을 렌더링할 수 없습니다: %w", err)
+	}
+	return out.String(), nil
+}
+
+// Refs는 텍스트의 템플릿이 참조하는 변수 이름을 정렬하여 반환합니다. 템플릿 동작이 없으면 nil을 반환합니다.
+// 렌더링하기 전에 비용이 드는 변수를 필요할 때만 준비하는 데 사용합니다.
+func Refs(text string) ([]string, error) {
+	if !IsTemplate(text) {
+		return nil, nil
+	}
+	tmpl, err := template.New("").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("템플릿을 해석할 수 없습니다: %w", err)
+	}
+	return refs(tmpl), nil
+}
+
+// refs는 템플릿이 참조하는 변수 이름을 정렬하여 반환합니다
+func refs(tmpl *template.Template) []string {
+	found := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			collectRefs(t.Tree.Root, true, found)
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// undefined는 템플릿이 참조하지만 vars에 없는 변수 이름을 정렬하여 반환합니다
+func undefined(tmpl *template.Template, vars map[string]string) []string {
+	var missing []string
+	for _, name := range refs(tmpl) {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// collectRefs는 노드에서 최상위 변수 참조를 모읍니다.
+// root가 false이면 range/with 안쪽처럼 점(.)이 변수 맵이 아닌 곳이므로 .이름은 모으지 않고 $.이름만 모읍니다.
+func collectRefs(node parse.Node, root bool, refs map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectRefs(child, root, refs)
+		}
+	case *parse.ActionNode:
+		collectRefs(n.Pipe, root, refs)
+	case *parse.IfNode:
+		collectRefs(n.Pipe, root, refs)
+		collectRefs(n.List, root, refs)
+		collectRefs(n.ElseList, root, refs)
+	case *parse.RangeNode:
+		collectRefs(n.Pipe, root, refs)
+		collectRefs(n.List, false, refs)
+		collectRefs(n.ElseList, root, refs)
+	case *parse.WithNode:
+		collectRefs(n.Pipe, root, refs)
+		collectRefs(n.List, false, refs)
+		collectRefs(n.ElseList, root, refs)
+	case *parse.TemplateNode:
+		collectRefs(n.Pipe, root, refs)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectRefs(cmd, root, refs)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectRefs(arg, root, refs)
+		}
+	case *parse.ChainNode:
+		collectRefs(n.Node, root, refs)
+	case *parse.FieldNode:
+		if root {
+			refs[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			refs[n.Ident[1]] = true
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package render
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	vars := map[string]string{"Module": "github.com/acme/api", "Language": "Go", "team": "platform"}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"그대로 적용되는 프롬프트", "그대로 적용되는 프롬프트"},
+		{"{{.Language}} 프로젝트 {{.Module}}", "Go 프로젝트 github.com/acme/api"},
+		{"{{if .team}}팀: {{.team}}{{end}}", "팀: platform"},
+		{"{{with .Language}}{{.}} 규칙 ({{$.team}}){{end}}", "Go 규칙 (platform)"},
+		{`{{"{{"}}.이스케이프}}`, "{{.이스케이프}}"},
+	}
+	for _, tt := range tests {
+		got, err := Render("test", tt.text, vars)
+		if err != nil {
+			t.Errorf("%q 렌더링 실패: %v", tt.text, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%q: 예상 %q, 실제 %q", tt.text, tt.expected, got)
+		}
+	}
+}
+
+func TestRender_UndefinedVariables(t *testing.T) {
+	_, err := Render("test", "{{.owner}} {{.Module}} {{if .env}}{{.region}}{{end}} {{.owner}}", map[string]string{"Module": "x"})
+
+	var undefinedErr *UndefinedError
+	if !errors.As(err, &undefinedErr) {
+		t.Fatalf("UndefinedError를 기대했습니다: %v", err)
+	}
+	if got := strings.Join(undefinedErr.Names, ","); got != "env,owner,region" {
+		t.Errorf("정의되지 않은 변수 목록이 일치하지 않습니다: %s", got)
+	}
+
+	if _, err := Render("test", "{{.Module", nil); err == nil || errors.As(err, &undefinedErr) {
+		t.Errorf("문법 오류를 기대했습니다: %v", err)
+	}
+}
+
+func TestRefs(t *testing.T) {
+	refs, err := Refs("{{.Language}} {{range .items}}{{.Name}}{{end}} {{$.Module}}")
+	if err != nil {
+		t.Fatalf("참조 찾기 실패: %v", err)
+	}
+	if got := strings.Join(refs, ","); got != "Language,Module,items" {
+		t.Errorf("참조한 변수 목록이 일치하지 않습니다: %s", got)
+	}
+
+	if refs, err := Refs("템플릿이 아닌 프롬프트"); err != nil || refs != nil {
+		t.Errorf("템플릿 동작이 없으면 참조가 없어야 합니다: %v, %v", refs, err)
+	}
+	if _, err := Refs("${{ secrets.TOKEN }}"); err == nil {
+		t.Error("해석할 수 없는 템플릿은 오류를 반환해야 합니다")
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"team", "Module", "_x", "api_v2"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("%q는 올바른 이름입니다: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "2x", "my-var", "a.b", "팀"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("%q는 오류를 반환해야 합니다", name)
+		}
+	}
+}
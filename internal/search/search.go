@@ -0,0 +1,109 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Line은 검색 결과의 줄 하나입니다
+type Line struct {
+	Number  int     // 1부터 시작하는 줄 번호
+	Text    string  // 줄 내용
+	Matches [][]int // 일치한 구간의 바이트 위치 (비어 있으면 문맥 줄)
+}
+
+// IsMatch는 검색어와 일치한 줄인지 확인합니다
+func (l Line) IsMatch() bool {
+	return len(l.Matches) > 0
+}
+
+// Hunk는 일치한 줄과 앞뒤 문맥 줄이 이어진 묶음입니다
+type Hunk []Line
+
+// Compile은 검색어로 정규식을 만듭니다.
+// regex가 아니면 검색어를 문자열 그대로 찾으며, caseSensitive가 아니면 대소문자를 구분하지 않습니다.
+func Compile(query string, regex, caseSensitive bool) (*regexp.Regexp, error) {
+	if query == "" {
+		return nil, fmt.Errorf("검색어가 비어 있습니다")
+	}
+
+	pattern := query
+	if !regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 정규식입니다: %w", err)
+	}
+	return re, nil
+}
+
+// Find는 텍스트에서 정규식과 일치하는 줄을 찾아 앞뒤 context줄과 함께 묶어 반환합니다.
+// 빈 문자열에만 일치하는 줄은 일치한 것으로 보지 않습니다.
+func Find(text string, re *regexp.Regexp, context int) []Hunk {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	// 줄마다 일치한 구간 찾기
+	matches := make([][][]int, len(lines))
+	var matched []int
+	for i, line := range lines {
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			if loc[1] > loc[0] {
+				matches[i] = append(matches[i], loc)
+			}
+		}
+		if len(matches[i]) > 0 {
+			matched = append(matched, i)
+		}
+	}
+
+	// 문맥이 겹치거나 맞닿는 일치 줄은 한 묶음으로
+	var hunks []Hunk
+	for j := 0; j < len(matched); {
+		start := max(matched[j]-context, 0)
+		end := min(matched[j]+context, len(lines)-1)
+		for j++; j < len(matched) && matched[j]-context <= end+1; j++ {
+			end = min(matched[j]+context, len(lines)-1)
+		}
+
+		hunk := make(Hunk, 0, end-start+1)
+		for i := start; i <= end; i++ {
+			hunk = append(hunk, Line{Number: i + 1, Text: lines[i], Matches: matches[i]})
+		}
+		hunks = append(hunks, hunk)
+	}
+	return hunks
+}
+
+// Count는 묶음에서 일치한 줄 수를 반환합니다
+func Count(hunks []Hunk) int {
+	count := 0
+	for _, hunk := range hunks {
+		for _, line := range hunk {
+			if line.IsMatch() {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Highlight는 줄에서 일치한 구간을 open과 close로 감쌉니다
+func Highlight(line Line, open, close string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range line.Matches {
+		out.WriteString(line.Text[last:loc[0]])
+		out.WriteString(open)
+		out.WriteString(line.Text[loc[0]:loc[1]])
+		out.WriteString(close)
+		last = loc[1]
+	}
+	out.WriteString(line.Text[last:])
+	return out.String()
+}
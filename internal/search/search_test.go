@@ -0,0 +1,67 @@
+package search
+
+import (
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	text := "첫 줄\n에러는 감싸서 반환\n중간\n중간\n중간\n중간\nError 로그\n끝\n"
+
+	re, err := Compile("에러|error", true, false)
+	if err != nil {
+		t.Fatalf("정규식 컴파일 실패: %v", err)
+	}
+
+	hunks := Find(text, re, 1)
+	if len(hunks) != 2 {
+		t.Fatalf("묶음 수가 일치하지 않습니다: %+v", hunks)
+	}
+	if first := hunks[0]; len(first) != 3 || first[0].Number != 1 || !first[1].IsMatch() || first[2].IsMatch() {
+		t.Errorf("첫 묶음이 일치하지 않습니다: %+v", first)
+	}
+	if second := hunks[1]; len(second) != 3 || second[1].Number != 7 {
+		t.Errorf("두 번째 묶음이 일치하지 않습니다: %+v", second)
+	}
+	if Count(hunks) != 2 {
+		t.Errorf("일치한 줄 수가 일치하지 않습니다: %d", Count(hunks))
+	}
+
+	// 문맥이 맞닿으면 한 묶음
+	if hunks := Find(text, re, 2); len(hunks) != 1 {
+		t.Errorf("문맥이 맞닿는 일치는 한 묶음이어야 합니다: %d", len(hunks))
+	}
+}
+
+func TestCompile(t *testing.T) {
+	// 문자열 검색은 정규식 문자를 그대로 찾음
+	re, err := Compile("fmt.Errorf(", false, false)
+	if err != nil {
+		t.Fatalf("검색어 컴파일 실패: %v", err)
+	}
+	if !re.MatchString("FMT.ERRORF(x)") || re.MatchString("fmtxErrorf(") {
+		t.Error("문자열 검색은 대소문자 구분 없이 그대로 일치해야 합니다")
+	}
+
+	re, _ = Compile("Error", false, true)
+	if re.MatchString("error") {
+		t.Error("대소문자를 구분해야 합니다")
+	}
+
+	if _, err := Compile("(", true, false); err == nil {
+		t.Error("잘못된 정규식은 오류를 반환해야 합니다")
+	}
+	if _, err := Compile("", false, false); err == nil {
+		t.Error("빈 검색어는 오류를 반환해야 합니다")
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	re, _ := Compile("a*b", true, false)
+	hunks := Find("xxab ab\nccc", re, 0)
+	if len(hunks) != 1 || len(hunks[0]) != 1 {
+		t.Fatalf("빈 문자열 일치는 무시해야 합니다: %+v", hunks)
+	}
+	if got := Highlight(hunks[0][0], "[", "]"); got != "xx[ab] [ab]" {
+		t.Errorf("강조 결과가 일치하지 않습니다: %s", got)
+	}
+}
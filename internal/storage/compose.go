@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxComposeDepth는 포함과 상속을 따라갈 최대 깊이입니다
+const maxComposeDepth = 16
+
+// sharedRefAlias는 프롬프트 참조에서 SharedTool 대신 쓸 수 있는 이름입니다 (shared/base)
+const sharedRefAlias = "shared"
+
+// includePattern은 다른 프롬프트를 포함하는 지시어입니다 ({{include "<도구>/<카테고리>"}})
+var includePattern = regexp.MustCompile(`\{\{\s*include\s+"([^"]*)"\s*\}\}`)
+
+// ParseRef는 프롬프트 참조(<도구>/<카테고리>)를 도구와 카테고리로 나눕니다.
+// 첫 번째 경로가 도구이며, shared는 @shared와 같습니다.
+func ParseRef(ref string) (tool, category string, err error) {
+	tool, category, ok := strings.Cut(strings.TrimSpace(ref), "/")
+	if !ok || tool == "" || category == "" {
+		return "", "", fmt.Errorf("프롬프트 참조는 <도구>/<카테고리> 형식이어야 합니다: %q", ref)
+	}
+	if tool == sharedRefAlias {
+		tool = SharedTool
+	}
+	if err := validatePrompt(tool, category); err != nil {
+		return "", "", fmt.Errorf("잘못된 프롬프트 참조입니다: %q: %w", ref, err)
+	}
+	return tool, category, nil
+}
+
+// ExpandPrompt는 프롬프트를 찾아 부모 프롬프트 상속(메타데이터의 extends)과
+// {{include "<도구>/<카테고리>"}} 지시어를 펼친 내용을 반환합니다.
+// 부모 프롬프트의 내용이 먼저 오고 빈 줄 하나를 사이에 두고 프롬프트 내용이 이어집니다.
+// 참조한 프롬프트도 ResolvePrompt와 같은 순서(범위별 도구 전용, 공유)로 찾으며, 순환 참조는 오류입니다.
+// 템플릿으로 렌더링하는 프롬프트에 --raw 프롬프트를 포함하면 포함한 내용의 {{는 그대로 출력되도록 이스케이프합니다.
+func (l *Layers) ExpandPrompt(tool, category string) (string, Origin, error) {
+	prompt, origin, err := l.ResolvePrompt(tool, category)
+	if err != nil {
+		return "", Origin{}, err
+	}
+	meta, err := l.GetMeta(origin, category)
+	if err != nil {
+		return "", Origin{}, err
+	}
+
+	expanded, err := l.expand(prompt, meta, []string{origin.Tool + "/" + category})
+	if err != nil {
+		return "", Origin{}, err
+	}
+	return expanded, origin, nil
+}
+
+// expand는 프롬프트 하나의 상속과 포함을 펼칩니다. stack은 지금까지 따라온 참조 경로입니다.
+func (l *Layers) expand(prompt string, meta PromptMeta, stack []string) (string, error) {
+	// 포함 지시어 펼치기
+	var expandErr error
+	body := includePattern.ReplaceAllStringFunc(prompt, func(directive string) string {
+		if expandErr != nil {
+			return directive
+		}
+		ref := includePattern.FindStringSubmatch(directive)[1]
+		included, includedMeta, err := l.expandRef(ref, stack)
+		if err != nil {
+			expandErr = err
+			return directive
+		}
+		if includedMeta.Raw && !meta.Raw {
+			included = strings.ReplaceAll(included, "{{", `{{"{{"}}`)
+		}
+		return strings.TrimRight(included, "\n")
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+
+	if meta.Extends == "" {
+		return body, nil
+	}
+
+	// 부모 프롬프트 뒤에 이어 붙이기
+	parent, parentMeta, err := l.expandRef(meta.Extends, stack)
+	if err != nil {
+		return "", err
+	}
+	if parentMeta.Raw && !meta.Raw {
+		parent = strings.ReplaceAll(parent, "{{", `{{"{{"}}`)
+	}
+	return strings.TrimRight(parent, "\n") + "\n\n" + body, nil
+}
+
+// expandRef는 참조한 프롬프트를 찾아 펼친 내용과 메타데이터를 반환합니다
+func (l *Layers) expandRef(ref string, stack []string) (string, PromptMeta, error) {
+	tool, category, err := ParseRef(ref)
+	if err != nil {
+		return "", PromptMeta{}, fmt.Errorf("%s: %w", stack[len(stack)-1], err)
+	}
+
+	prompt, origin, err := l.ResolvePrompt(tool, category)
+	if err != nil {
+		return "", PromptMeta{}, fmt.Errorf("%s에서 참조한 %w", stack[len(stack)-1], err)
+	}
+
+	// 공유 프롬프트로 찾은 참조도 같은 프롬프트로 보도록 찾은 위치로 비교
+	key := origin.Tool + "/" + category
+	for i, visited := range stack {
+		if visited == key {
+			cycle := append(append([]string{}, stack[i:]...), key)
+			return "", PromptMeta{}, fmt.Errorf("프롬프트 참조가 순환합니다: %s", strings.Join(cycle, " → "))
+		}
+	}
+	if len(stack) >= maxComposeDepth {
+		return "", PromptMeta{}, fmt.Errorf("프롬프트 참조가 너무 깊습니다 (최대 %d단계): %s", maxComposeDepth, strings.Join(stack, " → "))
+	}
+	meta, err := l.GetMeta(origin, category)
+	if err != nil {
+		return "", PromptMeta{}, err
+	}
+
+	expanded, err := l.expand(prompt, meta, append(stack, key))
+	if err != nil {
+		return "", PromptMeta{}, err
+	}
+	return expanded, meta, nil
+}
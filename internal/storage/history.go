@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// revisionTimeFormat은 리비전 파일 이름에 기록하는 시각 형식입니다
+const revisionTimeFormat = "20060102T150405Z"
+
+// Revision은 저장된 프롬프트의 리비전 하나입니다
+type Revision struct {
+	Number int       // 1부터 시작하는 리비전 번호
+	Time   time.Time // 저장 시각 (UTC)
+	path   string
+}
+
+// revisionDir는 프롬프트의 리비전 디렉터리 경로를 반환합니다.
+// 중첩 카테고리의 리비전 디렉터리는 상위 카테고리의 리비전 디렉터리 안에 있으므로, 리비전은 파일 단위로 다룹니다.
+func (s *Storage) revisionDir(tool, category string) string {
+	return filepath.Join(s.historyRoot(), tool, filepath.FromSlash(category))
+}
+
+// ListRevisions는 프롬프트의 리비전을 오래된 순서로 반환합니다
+func (s *Storage) ListRevisions(tool, category string) ([]Revision, error) {
+	if err := validatePrompt(tool, category); err != nil {
+		return nil, err
+	}
+	dir := s.revisionDir(tool, category)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Revision{}, nil // 빈 목록 반환
+		}
+		return nil, fmt.Errorf("리비전 목록을 가져올 수 없습니다: %w", err)
+	}
+
+	var revisions []Revision
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".txt" {
+			continue
+		}
+
+		// 파일 이름: <번호>-<시각>.txt
+		number, stamp, found := strings.Cut(strings.TrimSuffix(entry.Name(), ".txt"), "-")
+		if !found {
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			continue
+		}
+		t, _ := time.Parse(revisionTimeFormat, stamp)
+
+		revisions = append(revisions, Revision{Number: n, Time: t, path: filepath.Join(dir, entry.Name())})
+	}
+
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Number < revisions[j].Number })
+	return revisions, nil
+}
+
+// GetRevision은 리비전 번호에 해당하는 프롬프트 내용을 가져옵니다
+func (s *Storage) GetRevision(tool, category string, number int) (string, error) {
+	revisions, err := s.ListRevisions(tool, category)
+	if err != nil {
+		return "", err
+	}
+
+	for _, revision := range revisions {
+		if revision.Number == number {
+			content, err := os.ReadFile(revision.path)
+			if err != nil {
+				return "", fmt.Errorf("리비전을 읽을 수 없습니다: %w", err)
+			}
+			return string(content), nil
+		}
+	}
+
+	return "", fmt.Errorf("리비전을 찾을 수 없습니다: %s/%s #%d", tool, category, number)
+}
+
+// RollbackPrompt는 프롬프트를 이전 리비전의 내용으로 되돌립니다.
+// 되돌린 내용은 새 리비전으로 기록되므로 이후 리비전도 사라지지 않습니다.
+func (s *Storage) RollbackPrompt(tool, category string, number int) error {
+	content, err := s.GetRevision(tool, category, number)
+	if err != nil {
+		return err
+	}
+	return s.SavePrompt(tool, category, content)
+}
+
+// moveRevisions는 프롬프트의 리비전 파일을 다른 카테고리로 옮깁니다
+func (s *Storage) moveRevisions(tool, from, to string) error {
+	revisions, err := s.ListRevisions(tool, from)
+	if err != nil || len(revisions) == 0 {
+		return err
+	}
+
+	dir := s.revisionDir(tool, to)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("리비전 디렉터리를 생성할 수 없습니다: %w", err)
+	}
+	for _, revision := range revisions {
+		if err := os.Rename(revision.path, filepath.Join(dir, filepath.Base(revision.path))); err != nil {
+			return fmt.Errorf("리비전 기록을 옮길 수 없습니다: %w", err)
+		}
+	}
+	RemoveEmptyDirs(s.revisionDir(tool, from), filepath.Join(s.historyRoot(), tool))
+	return nil
+}
+
+// recordRevision은 프롬프트 내용을 새 리비전으로 기록합니다. 마지막 리비전과 같은 내용이면 기록하지 않습니다.
+func (s *Storage) recordRevision(tool, category, prompt string) error {
+	revisions, err := s.ListRevisions(tool, category)
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	if len(revisions) > 0 {
+		last := revisions[len(revisions)-1]
+		if content, err := os.ReadFile(last.path); err == nil && string(content) == prompt {
+			return nil
+		}
+		next = last.Number + 1
+	}
+
+	dir := s.revisionDir(tool, category)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("리비전 디렉터리를 생성할 수 없습니다: %w", err)
+	}
+
+	name := fmt.Sprintf("%04d-%s.txt", next, time.Now().UTC().Format(revisionTimeFormat))
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(prompt), 0644); err != nil {
+		return fmt.Errorf("리비전을 저장할 수 없습니다: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// 저장소 범위 (우선순위가 높은 순서)
+const (
+	ScopeProject = "project" // 프로젝트 저장소 (<프로젝트>/.aide)
+	ScopeTeam    = "team"    // 팀 저장소 ($AIDE_TEAM_DIR)
+	ScopeUser    = "user"    // 사용자 저장소 (~/.aide, 기본값)
+	ScopeSystem  = "system"  // 시스템 저장소 (/etc/aide)
+)
+
+// Scopes는 프롬프트를 찾는 우선순위 순서의 범위 목록입니다
+var Scopes = []string{ScopeProject, ScopeTeam, ScopeUser, ScopeSystem}
+
+// TeamDirEnv는 팀 저장소 디렉터리를 지정하는 환경 변수입니다
+const TeamDirEnv = "AIDE_TEAM_DIR"
+
+// Layer는 범위 하나의 저장소입니다
+type Layer struct {
+	Scope string
+	Store *Storage
+}
+
+// Origin은 프롬프트를 찾은 위치입니다
+type Origin struct {
+	Scope string // 프롬프트가 있는 저장소 범위
+	Tool  string // 프롬프트가 저장된 도구 (공유 프롬프트면 SharedTool)
+}
+
+// Shared는 공유 프롬프트인지 확인합니다
+func (o Origin) Shared() bool {
+	return o.Tool == SharedTool
+}
+
+// Layers는 여러 범위의 저장소를 우선순위대로 겹쳐 프롬프트를 찾습니다.
+// 범위마다 도구 전용 프롬프트를 먼저, 공유 프롬프트를 다음으로 찾으므로
+// 우선순위가 높은 범위의 공유 프롬프트가 낮은 범위의 도구 전용 프롬프트보다 우선합니다.
+type Layers struct {
+	layers []Layer
+}
+
+// NewLayers는 사용자 저장소와 프로젝트 디렉터리로 계층화된 저장소를 생성합니다.
+// projectDir가 비어 있으면 프로젝트 저장소 없이, $AIDE_TEAM_DIR가 비어 있으면 팀 저장소 없이 구성합니다.
+func NewLayers(user *Storage, projectDir string) *Layers {
+	l := &Layers{}
+	if projectDir != "" {
+		l.layers = append(l.layers, Layer{ScopeProject, Open(filepath.Join(projectDir, StoreDirName))})
+	}
+	if teamDir := os.Getenv(TeamDirEnv); teamDir != "" {
+		l.layers = append(l.layers, Layer{ScopeTeam, Open(teamDir)})
+	}
+	l.layers = append(l.layers, Layer{ScopeUser, user})
+	l.layers = append(l.layers, Layer{ScopeSystem, Open(SystemDir())})
+	return l
+}
+
+// SystemDir는 시스템 저장소 디렉터리 경로를 반환합니다
+func SystemDir() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("ProgramData"), "aide")
+	}
+	return "/etc/aide"
+}
+
+// FindProjectDir는 startDir부터 상위로 올라가며 프로젝트 저장소(.aide 디렉터리)가 있는 디렉터리를 찾습니다.
+// 사용자 저장소(userDir)와 기본 사용자 저장소(~/.aide)는 프로젝트 저장소로 보지 않으므로
+// --store나 $AIDE_HOME으로 다른 저장소를 지정해도 ~/.aide가 프로젝트 저장소로 끼어들지 않습니다.
+// 찾지 못하면 빈 문자열을 반환합니다.
+func FindProjectDir(startDir, userDir string) string {
+	excluded := map[string]bool{userDir: true}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		excluded[filepath.Join(homeDir, StoreDirName)] = true
+	}
+
+	dir := startDir
+	for {
+		candidate := filepath.Join(dir, StoreDirName)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() && !excluded[candidate] {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Layers는 우선순위 순서의 저장소 목록을 반환합니다
+func (l *Layers) Layers() []Layer {
+	return l.layers
+}
+
+// Store는 범위의 저장소를 반환합니다
+func (l *Layers) Store(scope string) (*Storage, error) {
+	for _, layer := range l.layers {
+		if layer.Scope == scope {
+			return layer.Store, nil
+		}
+	}
+
+	switch scope {
+	case ScopeProject:
+		return nil, fmt.Errorf("프로젝트 저장소가 없습니다 (프로젝트 루트에 %s 디렉터리를 만들어 주세요)", StoreDirName)
+	case ScopeTeam:
+		return nil, fmt.Errorf("팀 저장소가 설정되지 않았습니다 ($%s를 지정해 주세요)", TeamDirEnv)
+	default:
+		return nil, fmt.Errorf("지원되지 않는 저장소 범위입니다: %s (project, team, user, system 중 하나)", scope)
+	}
+}
+
+// find는 범위 순서대로 도구 전용 프롬프트와 공유 프롬프트를 찾습니다
+func (l *Layers) find(tool, category string) (*Storage, Origin, bool) {
+	for _, layer := range l.layers {
+		if layer.Store.PromptExists(tool, category) {
+			return layer.Store, Origin{Scope: layer.Scope, Tool: tool}, true
+		}
+		if tool != SharedTool && layer.Store.PromptExists(SharedTool, category) {
+			return layer.Store, Origin{Scope: layer.Scope, Tool: SharedTool}, true
+		}
+	}
+	return nil, Origin{}, false
+}
+
+// ResolvePrompt는 우선순위가 가장 높은 범위에서 프롬프트를 가져오고, 찾은 위치를 함께 반환합니다
+func (l *Layers) ResolvePrompt(tool, category string) (string, Origin, error) {
+	store, origin, ok := l.find(tool, category)
+	if !ok {
+		return "", Origin{}, fmt.Errorf("%w: %s/%s (%s에도 없음)", ErrPromptNotFound, tool, category, SharedTool)
+	}
+
+	prompt, err := store.GetPrompt(origin.Tool, category)
+	return prompt, origin, err
+}
+
+// GetMeta는 ResolvePrompt로 찾은 위치의 프롬프트 메타데이터를 가져옵니다
+func (l *Layers) GetMeta(origin Origin, category string) (PromptMeta, error) {
+	store, err := l.Store(origin.Scope)
+	if err != nil {
+		return PromptMeta{}, err
+	}
+	return store.GetMeta(origin.Tool, category)
+}
+
+// ListPrompts는 도구에 적용할 수 있는 모든 카테고리와, 각 카테고리를 찾은 위치를 반환합니다
+func (l *Layers) ListPrompts(tool string) (map[string]Origin, error) {
+	names := make(map[string]bool)
+	for _, layer := range l.layers {
+		for _, namespace := range []string{tool, SharedTool} {
+			categories, err := layer.Store.ListPrompts(namespace)
+			if err != nil {
+				return nil, err
+			}
+			for _, category := range categories {
+				names[category] = true
+			}
+		}
+	}
+
+	result := make(map[string]Origin, len(names))
+	for category := range names {
+		if _, origin, ok := l.find(tool, category); ok {
+			result[category] = origin
+		}
+	}
+	return result, nil
+}
+
+// ListAllPrompts는 모든 범위에 저장된 도구별 프롬프트를 나열합니다.
+// 여러 범위에 같은 프롬프트가 있으면 우선순위가 가장 높은 범위를 반환합니다.
+func (l *Layers) ListAllPrompts() (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string)
+
+	// 우선순위가 낮은 범위부터 덮어쓰기
+	for i := len(l.layers) - 1; i >= 0; i-- {
+		all, err := l.layers[i].Store.ListAllPrompts()
+		if err != nil {
+			return nil, err
+		}
+		for tool, categories := range all {
+			if result[tool] == nil {
+				result[tool] = make(map[string]string)
+			}
+			for _, category := range categories {
+				result[tool][category] = l.layers[i].Scope
+			}
+		}
+	}
+	return result, nil
+}
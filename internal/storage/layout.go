@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// StoreVersion은 현재 저장소 구조의 버전입니다.
+// 버전 1은 저장소 최상위에 도구 디렉터리를 두던 이전 구조입니다.
+const StoreVersion = 2
+
+// 저장소 내부 구조
+const (
+	storeFile  = "store.json" // 저장소 버전 등 메타데이터
+	PromptsDir = "prompts"    // 도구별 프롬프트 디렉터리의 루트 (저장소 기준)
+	toolsDir   = "tools"      // 도구 설정 (JSON)
+	historyDir = "history"    // 프롬프트 리비전
+
+	legacyHistoryDir = ".history" // 버전 1의 리비전 디렉터리
+)
+
+// storeMeta는 저장소 메타데이터 파일의 내용입니다
+type storeMeta struct {
+	Version int `json:"version"`
+}
+
+// detectLegacy는 저장소가 메타데이터 파일이 없는 이전 구조(버전 1)인지 확인합니다
+func detectLegacy(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, storeFile)); err == nil {
+		return false
+	}
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// promptsRoot는 도구별 프롬프트 디렉터리의 루트 경로를 반환합니다
+func (s *Storage) promptsRoot() string {
+	if s.legacy {
+		return s.baseDir
+	}
+	return filepath.Join(s.baseDir, PromptsDir)
+}
+
+// historyRoot는 리비전 디렉터리의 루트 경로를 반환합니다
+func (s *Storage) historyRoot() string {
+	if s.legacy {
+		return filepath.Join(s.baseDir, legacyHistoryDir)
+	}
+	return filepath.Join(s.baseDir, historyDir)
+}
+
+// toolsRoot는 도구 설정 디렉터리 경로를 반환합니다
+func (s *Storage) toolsRoot() string {
+	return filepath.Join(s.baseDir, toolsDir)
+}
+
+// ensureLayout은 저장소에 쓰기 전에 현재 구조를 준비합니다.
+// 이전 구조의 저장소는 한 번만 새 구조로 옮기고, 새 저장소에는 메타데이터 파일을 만듭니다.
+func (s *Storage) ensureLayout() error {
+	data, err := os.ReadFile(filepath.Join(s.baseDir, storeFile))
+	if err == nil {
+		var meta storeMeta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return fmt.Errorf("저장소 메타데이터를 파싱할 수 없습니다: %w", err)
+		}
+		if meta.Version > StoreVersion {
+			return fmt.Errorf("더 새로운 버전의 aide가 만든 저장소입니다 (저장소 버전 %d, 지원 버전 %d): %s", meta.Version, StoreVersion, s.baseDir)
+		}
+		s.legacy = false
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("저장소 메타데이터를 읽을 수 없습니다: %w", err)
+	}
+
+	if err := os.MkdirAll(s.baseDir, 0755); err != nil {
+		return fmt.Errorf("저장소 디렉터리를 생성할 수 없습니다: %w", err)
+	}
+	if err := s.migrateLegacy(); err != nil {
+		return fmt.Errorf("저장소를 새 구조로 옮길 수 없습니다: %w", err)
+	}
+	s.legacy = false
+
+	data, err = json.MarshalIndent(storeMeta{Version: StoreVersion}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("저장소 메타데이터를 직렬화할 수 없습니다: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.baseDir, storeFile), data, 0644); err != nil {
+		return fmt.Errorf("저장소 메타데이터를 저장할 수 없습니다: %w", err)
+	}
+	return nil
+}
+
+// migrateLegacy는 최상위의 도구 디렉터리를 prompts/ 아래로, .history/를 history/로 옮깁니다.
+// 도구 이름으로 쓸 수 없는 디렉터리와 파일은 그대로 둡니다.
+func (s *Storage) migrateLegacy() error {
+	entries, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		return err
+	}
+
+	root := filepath.Join(s.baseDir, PromptsDir)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == PromptsDir || name == historyDir || validatePromptTool(name) != nil {
+			continue
+		}
+		if err := os.MkdirAll(root, 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(s.baseDir, name), filepath.Join(root, name)); err != nil {
+			return err
+		}
+	}
+
+	legacyHistory := filepath.Join(s.baseDir, legacyHistoryDir)
+	if _, err := os.Stat(legacyHistory); err == nil {
+		if err := os.Rename(legacyHistory, filepath.Join(s.baseDir, historyDir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
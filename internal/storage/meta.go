@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// metaExt는 프롬프트 메타데이터 파일의 확장자입니다 (<카테고리>.meta.json)
+const metaExt = ".meta.json"
+
+// PromptMeta는 프롬프트 하나의 메타데이터입니다
+type PromptMeta struct {
+	Description string    `json:"description,omitempty"` // 프롬프트 설명
+	Tags        []string  `json:"tags,omitempty"`        // 태그
+	Globs       []string  `json:"globs,omitempty"`       // 프롬프트를 적용할 파일 패턴 (예: Cursor 규칙의 globs)
+	Author      string    `json:"author,omitempty"`      // 작성자
+	Raw         bool      `json:"raw,omitempty"`         // 템플릿으로 렌더링하지 않고 그대로 적용할지 여부
+	Extends     string    `json:"extends,omitempty"`     // 상속할 부모 프롬프트 참조 (<도구>/<카테고리>)
+	Created     time.Time `json:"created,omitzero"`      // 처음 저장한 시각
+	Updated     time.Time `json:"updated,omitzero"`      // 마지막으로 저장한 시각
+}
+
+// HasTag는 메타데이터에 태그가 있는지 확인합니다 (대소문자 구분 없음)
+func (m PromptMeta) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTags는 태그의 공백을 제거하고 빈 태그와 중복을 없앱니다
+func NormalizeTags(tags []string) []string {
+	var result []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || (PromptMeta{Tags: result}).HasTag(tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// metaPath는 프롬프트 메타데이터 파일 경로를 반환합니다. 이름은 미리 검증되어 있어야 합니다.
+func (s *Storage) metaPath(tool, category string) string {
+	return filepath.Join(s.promptsRoot(), tool, filepath.FromSlash(category)+metaExt)
+}
+
+// GetMeta는 프롬프트의 메타데이터를 가져옵니다. 메타데이터 파일이 없는 프롬프트는 빈 메타데이터를 반환합니다.
+func (s *Storage) GetMeta(tool, category string) (PromptMeta, error) {
+	var meta PromptMeta
+	if err := validatePrompt(tool, category); err != nil {
+		return meta, err
+	}
+
+	data, err := os.ReadFile(s.metaPath(tool, category))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return meta, nil
+		}
+		return meta, fmt.Errorf("프롬프트 메타데이터를 읽을 수 없습니다: %w", err)
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("프롬프트 메타데이터를 파싱할 수 없습니다 (%s/%s): %w", tool, category, err)
+	}
+	return meta, nil
+}
+
+// UpdateMeta는 저장된 프롬프트의 메타데이터를 update로 수정하여 저장합니다
+func (s *Storage) UpdateMeta(tool, category string, update func(meta *PromptMeta)) error {
+	if !s.PromptExists(tool, category) {
+		return fmt.Errorf("%w: %s/%s", ErrPromptNotFound, tool, category)
+	}
+	if err := s.ensureLayout(); err != nil {
+		return err
+	}
+
+	meta, err := s.GetMeta(tool, category)
+	if err != nil {
+		return err
+	}
+	update(&meta)
+	meta.Tags = NormalizeTags(meta.Tags)
+	return s.writeMeta(tool, category, meta)
+}
+
+// writeMeta는 메타데이터 파일을 저장합니다
+func (s *Storage) writeMeta(tool, category string, meta PromptMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("프롬프트 메타데이터를 직렬화할 수 없습니다: %w", err)
+	}
+	if err := os.WriteFile(s.metaPath(tool, category), data, 0644); err != nil {
+		return fmt.Errorf("프롬프트 메타데이터를 저장할 수 없습니다: %w", err)
+	}
+	return nil
+}
+
+// touchMeta는 프롬프트를 저장한 시각을 메타데이터에 기록합니다. created이면 처음 저장한 시각도 기록합니다.
+func (s *Storage) touchMeta(tool, category string, created bool) error {
+	meta, err := s.GetMeta(tool, category)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if created {
+		meta.Created = now
+	}
+	meta.Updated = now
+	return s.writeMeta(tool, category, meta)
+}
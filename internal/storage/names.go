@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// 이름 길이 제한
+const (
+	maxNameLength     = 64  // 도구 이름과 카테고리 경로 한 단계의 최대 길이
+	maxCategoryDepth  = 4   // 중첩 카테고리의 최대 단계 수 (예: go/errors는 2단계)
+	maxCategoryLength = 200 // 카테고리 전체 경로의 최대 길이
+)
+
+// reservedNames는 저장소 내부에서 사용하므로 도구 이름으로 쓸 수 없는 이름입니다
+var reservedNames = []string{toolsDir, PromptsDir, historyDir}
+
+// ErrInvalidName은 도구나 카테고리 이름이 규칙에 맞지 않을 때 반환됩니다
+var ErrInvalidName = errors.New("올바르지 않은 이름입니다")
+
+// ValidateToolName은 도구 이름을 검증합니다.
+// 문자, 숫자, '-', '_', '.'만 사용할 수 있고, 문자나 숫자로 시작해야 하며, 예약된 이름(tools, prompts, history)은 쓸 수 없습니다.
+func ValidateToolName(name string) error {
+	if err := validateSegment(name); err != nil {
+		return fmt.Errorf("%w: 도구 %q: %s", ErrInvalidName, name, err)
+	}
+	for _, reserved := range reservedNames {
+		if strings.EqualFold(name, reserved) {
+			return fmt.Errorf("%w: 도구 %q: 저장소에서 예약된 이름입니다", ErrInvalidName, name)
+		}
+	}
+	return nil
+}
+
+// ValidateCategory는 카테고리 이름을 검증합니다.
+// 'go/errors'처럼 '/'로 구분한 중첩 카테고리를 쓸 수 있으며, 각 단계는 도구 이름과 같은 규칙을 따릅니다.
+func ValidateCategory(category string) error {
+	if len(category) > maxCategoryLength {
+		return fmt.Errorf("%w: 카테고리 %q: %d바이트를 넘을 수 없습니다", ErrInvalidName, category, maxCategoryLength)
+	}
+
+	segments := strings.Split(category, "/")
+	if len(segments) > maxCategoryDepth {
+		return fmt.Errorf("%w: 카테고리 %q: 최대 %d단계까지 중첩할 수 있습니다", ErrInvalidName, category, maxCategoryDepth)
+	}
+	for _, segment := range segments {
+		if err := validateSegment(segment); err != nil {
+			return fmt.Errorf("%w: 카테고리 %q: %s", ErrInvalidName, category, err)
+		}
+	}
+	return nil
+}
+
+// validatePromptTool은 프롬프트를 저장할 도구 이름(또는 공유 네임스페이스)을 검증합니다
+func validatePromptTool(tool string) error {
+	if tool == SharedTool {
+		return nil
+	}
+	return ValidateToolName(tool)
+}
+
+// validatePrompt는 프롬프트의 도구와 카테고리 이름을 검증합니다
+func validatePrompt(tool, category string) error {
+	if err := validatePromptTool(tool); err != nil {
+		return err
+	}
+	return ValidateCategory(category)
+}
+
+// validateSegment는 경로 한 단계로 쓰일 이름을 검증합니다
+func validateSegment(name string) error {
+	switch {
+	case name == "":
+		return errors.New("비어 있을 수 없습니다")
+	case len(name) > maxNameLength:
+		return fmt.Errorf("%d바이트를 넘을 수 없습니다", maxNameLength)
+	}
+
+	for i, r := range name {
+		alnum := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if i == 0 && !alnum {
+			return errors.New("문자나 숫자로 시작해야 합니다")
+		}
+		if !alnum && r != '-' && r != '_' && r != '.' {
+			return fmt.Errorf("사용할 수 없는 문자가 있습니다: %q (문자, 숫자, '-', '_', '.'만 사용 가능)", r)
+		}
+	}
+	return nil
+}
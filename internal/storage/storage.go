@@ -2,69 +2,182 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
+// 도구 설정 파일 형식
+const (
+	FormatText = "text" // 텍스트로 aide 영역을 추가 (기본값)
+	FormatJSON = "json" // 프롬프트를 JSON 객체로 보고 깊게 병합
+	FormatYAML = "yaml" // 프롬프트를 YAML 매핑으로 보고 깊게 병합
+	FormatTOML = "toml" // 프롬프트를 TOML 테이블로 보고 깊게 병합
+)
+
+// 도구 출력 방식
+const (
+	OutputFile      = "file"      // 파일 하나에 모든 카테고리를 기록 (기본값)
+	OutputDirectory = "directory" // 디렉터리 아래에 카테고리마다 파일 하나를 기록
+)
+
+// DefaultFilePattern은 디렉터리 출력에서 사용하는 기본 파일 이름 패턴입니다
+const DefaultFilePattern = "{category}"
+
+// DefaultExtension은 디렉터리 출력에서 사용하는 기본 확장자입니다
+const DefaultExtension = ".md"
+
 // ToolConfig는 도구별 설정을 저장하는 구조체입니다
 type ToolConfig struct {
-	Name        string `json:"name"`        // 도구 이름
-	FileName    string `json:"fileName"`    // 생성할 파일명
-	Description string `json:"description"` // 파일 설명
-	Header      string `json:"header"`      // 파일 헤더 (선택사항)
-	Separator   string `json:"separator"`   // 프롬프트 구분자
+	Name        string `json:"name"`             // 도구 이름
+	FileName    string `json:"fileName"`         // 생성할 파일명
+	Description string `json:"description"`      // 파일 설명
+	Header      string `json:"header"`           // 파일 헤더 (선택사항)
+	Separator   string `json:"separator"`        // 프롬프트 구분자
+	Format      string `json:"format,omitempty"` // 파일 형식 (기본값: text)
+
+	// 디렉터리 출력 설정 (Output이 directory이면 FileName은 디렉터리 경로)
+	Output      string `json:"output,omitempty"`      // 출력 방식 (기본값: file)
+	FilePattern string `json:"filePattern,omitempty"` // 규칙 파일 이름 패턴, {category}와 {tool} 치환 (기본값: {category})
+	Extension   string `json:"extension,omitempty"`   // 규칙 파일 확장자 (기본값: .md)
 }
 
+// IsDirectory는 카테고리마다 파일 하나를 만드는 디렉터리 출력 방식인지 확인합니다
+func (c *ToolConfig) IsDirectory() bool {
+	return c.Output == OutputDirectory
+}
+
+// StoreDirName은 사용자 저장소와 프로젝트 저장소의 디렉터리 이름입니다
+const StoreDirName = ".aide"
+
+// HomeEnv는 사용자 저장소 위치를 지정하는 환경 변수입니다
+const HomeEnv = "AIDE_HOME"
+
+// SharedTool은 어느 도구에나 적용할 수 있는 공유 프롬프트의 네임스페이스입니다
+const SharedTool = "@shared"
+
+// ErrPromptExists는 이동하거나 복사할 위치에 프롬프트가 이미 있을 때 반환됩니다
+var ErrPromptExists = errors.New("프롬프트가 이미 있습니다")
+
+// ErrPromptNotFound는 프롬프트가 없을 때 반환됩니다
+var ErrPromptNotFound = errors.New("프롬프트를 찾을 수 없습니다")
+
 // Storage는 프롬프트 저장소를 관리하는 구조체입니다
 type Storage struct {
 	baseDir string
+	legacy  bool // 메타데이터 파일이 없는 이전 구조 (쓰기 전에 새 구조로 옮김)
 }
 
-// New는 새로운 Storage 인스턴스를 생성합니다
-func New() (*Storage, error) {
-	homeDir, err := os.UserHomeDir()
+// New는 사용자 저장소의 Storage 인스턴스를 생성합니다.
+// 저장소 위치는 dir(--store), $AIDE_HOME, ~/.aide 순서로 정합니다 (UserDir 참고).
+func New(dir string) (*Storage, error) {
+	baseDir, err := UserDir(dir)
 	if err != nil {
-		return nil, fmt.Errorf("홈 디렉터리를 찾을 수 없습니다: %w", err)
+		return nil, err
 	}
 
-	baseDir := filepath.Join(homeDir, ".aide")
-	
-	// .aide 디렉터리가 없으면 생성
-	if err := os.MkdirAll(baseDir, 0755); err != nil {
-		return nil, fmt.Errorf("저장소 디렉터리를 생성할 수 없습니다: %w", err)
+	// 저장소 디렉터리가 없으면 생성하고, 이전 구조이면 새 구조로 옮김
+	s := Open(baseDir)
+	if err := s.ensureLayout(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Open은 디렉터리의 Storage 인스턴스를 반환합니다.
+// 디렉터리는 처음 저장할 때 생성되며, 이전 구조의 저장소는 그때 새 구조로 옮겨집니다.
+func Open(dir string) *Storage {
+	return &Storage{baseDir: dir, legacy: detectLegacy(dir)}
+}
+
+// UserDir는 사용자 저장소 디렉터리 경로를 반환합니다.
+// dir가 있으면 dir를, 없으면 $AIDE_HOME을, 둘 다 없으면 ~/.aide를 사용합니다.
+func UserDir(dir string) (string, error) {
+	if dir == "" {
+		dir = os.Getenv(HomeEnv)
+	}
+	if dir != "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return "", fmt.Errorf("저장소 경로를 확인할 수 없습니다: %w", err)
+		}
+		return abs, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("홈 디렉터리를 찾을 수 없습니다: %w", err)
 	}
+	return filepath.Join(homeDir, StoreDirName), nil
+}
 
-	return &Storage{baseDir: baseDir}, nil
+// Dir는 저장소 디렉터리 경로를 반환합니다
+func (s *Storage) Dir() string {
+	return s.baseDir
 }
 
-// SavePrompt는 프롬프트를 저장합니다
+// SavePrompt는 프롬프트를 저장합니다. 'go/errors' 같은 중첩 카테고리는 하위 디렉터리에 저장됩니다.
 func (s *Storage) SavePrompt(tool, category, prompt string) error {
-	// 도구별 디렉터리 생성
-	toolDir := filepath.Join(s.baseDir, tool)
-	if err := os.MkdirAll(toolDir, 0755); err != nil {
-		return fmt.Errorf("도구 디렉터리를 생성할 수 없습니다: %w", err)
+	if err := validatePrompt(tool, category); err != nil {
+		return err
+	}
+	if err := s.ensureLayout(); err != nil {
+		return err
 	}
 
 	// 프롬프트 파일 경로
-	promptFile := filepath.Join(toolDir, category+".txt")
+	promptFile := s.promptPath(tool, category)
+
+	// 도구별 디렉터리 생성 (중첩 카테고리는 하위 디렉터리까지)
+	if err := os.MkdirAll(filepath.Dir(promptFile), 0755); err != nil {
+		return fmt.Errorf("도구 디렉터리를 생성할 수 없습니다: %w", err)
+	}
+
+	// 리비전 기록이 없던 기존 프롬프트는 덮어쓰기 전에 첫 리비전으로 보관
+	previous, err := os.ReadFile(promptFile)
+	existed := err == nil
+	if existed {
+		revisions, err := s.ListRevisions(tool, category)
+		if err != nil {
+			return err
+		}
+		if len(revisions) == 0 {
+			if err := s.recordRevision(tool, category, string(previous)); err != nil {
+				return err
+			}
+		}
+	}
 	
 	// 프롬프트를 파일에 저장
 	if err := os.WriteFile(promptFile, []byte(prompt), 0644); err != nil {
 		return fmt.Errorf("프롬프트를 저장할 수 없습니다: %w", err)
 	}
 
-	return nil
+	// 저장 시각 기록
+	if err := s.touchMeta(tool, category, !existed); err != nil {
+		return err
+	}
+
+	// 저장할 때마다 리비전 기록
+	return s.recordRevision(tool, category, prompt)
 }
 
 // GetPrompt는 저장된 프롬프트를 가져옵니다
 func (s *Storage) GetPrompt(tool, category string) (string, error) {
-	promptFile := filepath.Join(s.baseDir, tool, category+".txt")
+	if err := validatePrompt(tool, category); err != nil {
+		return "", err
+	}
+
+	promptFile := s.promptPath(tool, category)
 	
 	content, err := os.ReadFile(promptFile)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return "", fmt.Errorf("프롬프트를 찾을 수 없습니다: %s/%s", tool, category)
+			return "", fmt.Errorf("%w: %s/%s", ErrPromptNotFound, tool, category)
 		}
 		return "", fmt.Errorf("프롬프트를 읽을 수 없습니다: %w", err)
 	}
@@ -72,26 +185,173 @@ func (s *Storage) GetPrompt(tool, category string) (string, error) {
 	return string(content), nil
 }
 
-// ListPrompts는 특정 도구의 모든 프롬프트 카테고리를 나열합니다
-func (s *Storage) ListPrompts(tool string) ([]string, error) {
-	toolDir := filepath.Join(s.baseDir, tool)
-	
-	entries, err := os.ReadDir(toolDir)
-	if err != nil {
+// promptPath는 프롬프트 파일 경로를 반환합니다. 이름은 미리 검증되어 있어야 합니다.
+func (s *Storage) promptPath(tool, category string) string {
+	return filepath.Join(s.promptsRoot(), tool, filepath.FromSlash(category)+".txt")
+}
+
+// PromptExists는 프롬프트가 저장되어 있는지 확인합니다. 이름이 올바르지 않으면 false를 반환합니다.
+func (s *Storage) PromptExists(tool, category string) bool {
+	if validatePrompt(tool, category) != nil {
+		return false
+	}
+	info, err := os.Stat(s.promptPath(tool, category))
+	return err == nil && !info.IsDir()
+}
+
+// DeletePrompt는 저장된 프롬프트를 삭제합니다.
+// 리비전 기록은 남겨두므로 'aide rollback'으로 되살릴 수 있습니다.
+func (s *Storage) DeletePrompt(tool, category string) error {
+	if err := validatePrompt(tool, category); err != nil {
+		return err
+	}
+	if err := s.ensureLayout(); err != nil {
+		return err
+	}
+
+	promptFile := s.promptPath(tool, category)
+	if err := os.Remove(promptFile); err != nil {
 		if os.IsNotExist(err) {
-			return []string{}, nil // 빈 목록 반환
+			return fmt.Errorf("%w: %s/%s", ErrPromptNotFound, tool, category)
 		}
-		return nil, fmt.Errorf("프롬프트 목록을 가져올 수 없습니다: %w", err)
+		return fmt.Errorf("프롬프트를 삭제할 수 없습니다: %w", err)
+	}
+	if err := os.Remove(s.metaPath(tool, category)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("프롬프트 메타데이터를 삭제할 수 없습니다: %w", err)
 	}
 
-	var categories []string
-	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".txt" {
-			// .txt 확장자 제거
-			name := entry.Name()
-			category := name[:len(name)-4]
+	// 중첩 카테고리의 빈 하위 디렉터리 정리
+	RemoveEmptyDirs(filepath.Dir(promptFile), filepath.Join(s.promptsRoot(), tool))
+	return nil
+}
+
+// RemoveEmptyDirs는 dir부터 stop 바로 아래까지 비어 있는 디렉터리를 삭제합니다.
+// stop 자체와 stop 바깥의 디렉터리는 삭제하지 않습니다.
+func RemoveEmptyDirs(dir, stop string) {
+	dir, stop = filepath.Clean(dir), filepath.Clean(stop)
+	for dir != stop && strings.HasPrefix(dir, stop+string(filepath.Separator)) {
+		if os.Remove(dir) != nil {
+			return // 비어 있지 않음
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// CopyPrompt는 프롬프트를 다른 도구나 카테고리로 복사합니다.
+// 대상에 프롬프트가 있으면 overwrite일 때만 덮어씁니다.
+func (s *Storage) CopyPrompt(srcTool, srcCategory, dstTool, dstCategory string, overwrite bool) error {
+	if srcTool == dstTool && srcCategory == dstCategory {
+		return fmt.Errorf("원본과 대상이 같습니다: %s/%s", srcTool, srcCategory)
+	}
+
+	prompt, err := s.GetPrompt(srcTool, srcCategory)
+	if err != nil {
+		return err
+	}
+	meta, err := s.GetMeta(srcTool, srcCategory)
+	if err != nil {
+		return err
+	}
+	if !overwrite && s.PromptExists(dstTool, dstCategory) {
+		return fmt.Errorf("%w: %s/%s", ErrPromptExists, dstTool, dstCategory)
+	}
+
+	if err := s.SavePrompt(dstTool, dstCategory, prompt); err != nil {
+		return err
+	}
+
+	// 설명, 태그 등은 원본을 따르고 저장 시각은 새로 기록
+	return s.UpdateMeta(dstTool, dstCategory, func(dst *PromptMeta) {
+		dst.Description, dst.Tags, dst.Globs, dst.Author = meta.Description, meta.Tags, meta.Globs, meta.Author
+		dst.Raw, dst.Extends = meta.Raw, meta.Extends
+	})
+}
+
+// RenamePrompt는 프롬프트의 카테고리 이름을 바꿉니다.
+// 새 이름에 리비전 기록이 없으면 기존 리비전 기록도 함께 옮깁니다.
+func (s *Storage) RenamePrompt(tool, from, to string, overwrite bool) error {
+	if err := validatePrompt(tool, from); err != nil {
+		return err
+	}
+	if err := ValidateCategory(to); err != nil {
+		return err
+	}
+	if err := s.ensureLayout(); err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("원본과 대상이 같습니다: %s/%s", tool, from)
+	}
+	if !s.PromptExists(tool, from) {
+		return fmt.Errorf("%w: %s/%s", ErrPromptNotFound, tool, from)
+	}
+	if !overwrite && s.PromptExists(tool, to) {
+		return fmt.Errorf("%w: %s/%s", ErrPromptExists, tool, to)
+	}
+
+	revisions, err := s.ListRevisions(tool, to)
+	if err != nil {
+		return err
+	}
+	if len(revisions) > 0 {
+		// 새 이름의 이력을 이어가도록 새 리비전으로 저장
+		if err := s.CopyPrompt(tool, from, tool, to, true); err != nil {
+			return err
+		}
+		return s.DeletePrompt(tool, from)
+	}
+
+	if err := s.moveRevisions(tool, from, to); err != nil {
+		return err
+	}
+
+	promptFile := s.promptPath(tool, to)
+	if err := os.MkdirAll(filepath.Dir(promptFile), 0755); err != nil {
+		return fmt.Errorf("도구 디렉터리를 생성할 수 없습니다: %w", err)
+	}
+	if err := os.Rename(s.promptPath(tool, from), promptFile); err != nil {
+		return fmt.Errorf("프롬프트 이름을 바꿀 수 없습니다: %w", err)
+	}
+	os.Remove(s.metaPath(tool, to)) // 덮어쓴 프롬프트의 메타데이터
+	if err := os.Rename(s.metaPath(tool, from), s.metaPath(tool, to)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("프롬프트 메타데이터를 옮길 수 없습니다: %w", err)
+	}
+	RemoveEmptyDirs(filepath.Dir(s.promptPath(tool, from)), filepath.Join(s.promptsRoot(), tool))
+	return nil
+}
+
+// ListPrompts는 특정 도구의 모든 프롬프트 카테고리를 나열합니다 (중첩 카테고리는 'go/errors' 형태)
+func (s *Storage) ListPrompts(tool string) ([]string, error) {
+	if err := validatePromptTool(tool); err != nil {
+		return nil, err
+	}
+	toolDir := filepath.Join(s.promptsRoot(), tool)
+
+	categories := []string{}
+	err := filepath.WalkDir(toolDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == toolDir {
+				return filepath.SkipDir // 빈 목록 반환
+			}
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".txt" {
+			return nil
+		}
+
+		// .txt 확장자 제거
+		rel, err := filepath.Rel(toolDir, path)
+		if err != nil {
+			return err
+		}
+		category := filepath.ToSlash(strings.TrimSuffix(rel, ".txt"))
+		if ValidateCategory(category) == nil {
 			categories = append(categories, category)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("프롬프트 목록을 가져올 수 없습니다: %w", err)
 	}
 
 	return categories, nil
@@ -101,7 +361,7 @@ func (s *Storage) ListPrompts(tool string) ([]string, error) {
 func (s *Storage) ListAllPrompts() (map[string][]string, error) {
 	result := make(map[string][]string)
 	
-	entries, err := os.ReadDir(s.baseDir)
+	entries, err := os.ReadDir(s.promptsRoot())
 	if err != nil {
 		if os.IsNotExist(err) {
 			return result, nil // 빈 맵 반환
@@ -110,7 +370,8 @@ func (s *Storage) ListAllPrompts() (map[string][]string, error) {
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() {
+		// 리비전 기록 등 숨김 디렉터리와 예약된 디렉터리는 도구가 아님
+		if entry.IsDir() && validatePromptTool(entry.Name()) == nil {
 			tool := entry.Name()
 			categories, err := s.ListPrompts(tool)
 			if err != nil {
@@ -124,20 +385,19 @@ func (s *Storage) ListAllPrompts() (map[string][]string, error) {
 }
 
 // SaveToolConfig는 도구 설정을 저장합니다
-func (s *Storage) SaveToolConfig(name, fileName, description, header, separator string) error {
-	config := ToolConfig{
-		Name:        name,
-		FileName:    fileName,
-		Description: description,
-		Header:      header,
-		Separator:   separator,
+func (s *Storage) SaveToolConfig(config ToolConfig) error {
+	if err := ValidateToolName(config.Name); err != nil {
+		return err
+	}
+	if err := s.ensureLayout(); err != nil {
+		return err
 	}
 
 	// 설정 파일 경로
-	configFile := filepath.Join(s.baseDir, "tools", name+".json")
+	configFile := filepath.Join(s.toolsRoot(), config.Name+".json")
 	
 	// tools 디렉터리 생성
-	toolsDir := filepath.Join(s.baseDir, "tools")
+	toolsDir := s.toolsRoot()
 	if err := os.MkdirAll(toolsDir, 0755); err != nil {
 		return fmt.Errorf("도구 설정 디렉터리를 생성할 수 없습니다: %w", err)
 	}
@@ -157,7 +417,11 @@ func (s *Storage) SaveToolConfig(name, fileName, description, header, separator
 
 // GetToolConfig는 도구 설정을 가져옵니다
 func (s *Storage) GetToolConfig(name string) (*ToolConfig, error) {
-	configFile := filepath.Join(s.baseDir, "tools", name+".json")
+	if err := ValidateToolName(name); err != nil {
+		return nil, err
+	}
+
+	configFile := filepath.Join(s.toolsRoot(), name+".json")
 	
 	data, err := os.ReadFile(configFile)
 	if err != nil {
@@ -175,9 +439,9 @@ func (s *Storage) GetToolConfig(name string) (*ToolConfig, error) {
 	return &config, nil
 }
 
-// ListToolConfigs는 등록된 모든 도구 목록을 반환합니다
+// ListToolConfigs는 사용자가 추가한 도구 설정 목록을 반환합니다 (기본 제공 도구를 재정의한 설정 포함)
 func (s *Storage) ListToolConfigs() ([]ToolConfig, error) {
-	toolsDir := filepath.Join(s.baseDir, "tools")
+	toolsDir := s.toolsRoot()
 	
 	entries, err := os.ReadDir(toolsDir)
 	if err != nil {
@@ -1,7 +1,11 @@
 package storage
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -84,4 +88,531 @@ func TestStorage_ListPrompts(t *testing.T) {
 			t.Errorf("카테고리 %s가 목록에 없습니다", expectedCategory)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestStorage_Revisions(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// 테스트용 Storage 생성
+	storage := &Storage{baseDir: tmpDir}
+
+	for _, prompt := range []string{"첫 번째", "두 번째", "두 번째", "세 번째"} {
+		if err := storage.SavePrompt("claude", "review", prompt); err != nil {
+			t.Fatalf("프롬프트 저장 실패: %v", err)
+		}
+	}
+
+	// 같은 내용을 다시 저장하면 리비전이 늘지 않아야 함
+	revisions, err := storage.ListRevisions("claude", "review")
+	if err != nil {
+		t.Fatalf("리비전 목록 가져오기 실패: %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("리비전 개수가 일치하지 않습니다. 예상: 3, 실제: %d", len(revisions))
+	}
+
+	content, err := storage.GetRevision("claude", "review", 1)
+	if err != nil || content != "첫 번째" {
+		t.Errorf("리비전 내용이 일치하지 않습니다: %q, %v", content, err)
+	}
+
+	// 되돌리면 새 리비전으로 기록
+	if err := storage.RollbackPrompt("claude", "review", 1); err != nil {
+		t.Fatalf("되돌리기 실패: %v", err)
+	}
+	current, err := storage.GetPrompt("claude", "review")
+	if err != nil || current != "첫 번째" {
+		t.Errorf("되돌린 내용이 일치하지 않습니다: %q, %v", current, err)
+	}
+	revisions, _ = storage.ListRevisions("claude", "review")
+	if len(revisions) != 4 || revisions[3].Number != 4 {
+		t.Errorf("되돌린 내용이 새 리비전으로 기록되어야 합니다: %+v", revisions)
+	}
+
+	if _, err := storage.GetRevision("claude", "review", 9); err == nil {
+		t.Error("없는 리비전은 오류를 반환해야 합니다")
+	}
+
+	// 리비전 기록은 도구 목록에 나타나지 않아야 함
+	all, err := storage.ListAllPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := all[historyDir]; ok {
+		t.Error("리비전 디렉터리가 도구로 나열되었습니다")
+	}
+}
+
+func TestStorage_CopyRenameDelete(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// 테스트용 Storage 생성
+	storage := &Storage{baseDir: tmpDir}
+
+	if err := storage.SavePrompt("claude", "review", "리뷰"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SavePrompt("claude", "review", "꼼꼼한 리뷰"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 다른 도구로 복사
+	if err := storage.CopyPrompt("claude", "review", "cursor", "review", false); err != nil {
+		t.Fatalf("복사 실패: %v", err)
+	}
+	if prompt, _ := storage.GetPrompt("cursor", "review"); prompt != "꼼꼼한 리뷰" {
+		t.Errorf("복사된 프롬프트가 일치하지 않습니다: %q", prompt)
+	}
+
+	// 대상이 있으면 overwrite 없이 실패
+	if err := storage.CopyPrompt("claude", "review", "cursor", "review", false); !errors.Is(err, ErrPromptExists) {
+		t.Errorf("ErrPromptExists를 기대했습니다: %v", err)
+	}
+
+	// 이름을 바꾸면 리비전 기록도 함께 이동
+	if err := storage.RenamePrompt("claude", "review", "code-review", false); err != nil {
+		t.Fatalf("이름 바꾸기 실패: %v", err)
+	}
+	if storage.PromptExists("claude", "review") {
+		t.Error("이전 이름의 프롬프트가 남아 있습니다")
+	}
+	revisions, _ := storage.ListRevisions("claude", "code-review")
+	if len(revisions) != 2 {
+		t.Errorf("리비전 기록이 옮겨지지 않았습니다: %+v", revisions)
+	}
+
+	// 삭제해도 리비전 기록은 남아 되돌릴 수 있음
+	if err := storage.DeletePrompt("claude", "code-review"); err != nil {
+		t.Fatalf("삭제 실패: %v", err)
+	}
+	if storage.PromptExists("claude", "code-review") {
+		t.Error("삭제된 프롬프트가 남아 있습니다")
+	}
+	if err := storage.RollbackPrompt("claude", "code-review", 2); err != nil {
+		t.Fatalf("삭제 후 되돌리기 실패: %v", err)
+	}
+	if err := storage.DeletePrompt("claude", "missing"); err == nil {
+		t.Error("없는 프롬프트 삭제는 오류를 반환해야 합니다")
+	}
+}
+
+func TestLayers_ResolvePrompt(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	project := Open(filepath.Join(tmpDir, "project"))
+	user := Open(filepath.Join(tmpDir, "user"))
+	system := Open(filepath.Join(tmpDir, "system"))
+	layers := &Layers{layers: []Layer{{ScopeProject, project}, {ScopeUser, user}, {ScopeSystem, system}}}
+
+	project.SavePrompt("claude", "review", "프로젝트 리뷰")
+	project.SavePrompt(SharedTool, "style", "프로젝트 공유 스타일")
+	user.SavePrompt("claude", "review", "사용자 리뷰")
+	user.SavePrompt("claude", "style", "사용자 스타일")
+	user.SavePrompt(SharedTool, "backend", "사용자 공유 백엔드")
+	system.SavePrompt("claude", "security", "시스템 보안")
+
+	tests := []struct {
+		tool, category string
+		prompt         string
+		origin         Origin
+	}{
+		{"claude", "review", "프로젝트 리뷰", Origin{ScopeProject, "claude"}},      // 높은 범위 우선
+		{"claude", "style", "프로젝트 공유 스타일", Origin{ScopeProject, SharedTool}}, // 높은 범위의 공유 프롬프트 우선
+		{"claude", "backend", "사용자 공유 백엔드", Origin{ScopeUser, SharedTool}},   // 공유 프롬프트로 대체
+		{"cursor", "security", "", Origin{}},                                 // 다른 도구의 프롬프트는 사용하지 않음
+		{"claude", "security", "시스템 보안", Origin{ScopeSystem, "claude"}},
+	}
+	for _, tt := range tests {
+		prompt, origin, err := layers.ResolvePrompt(tt.tool, tt.category)
+		if tt.prompt == "" {
+			if !errors.Is(err, ErrPromptNotFound) {
+				t.Errorf("%s/%s: ErrPromptNotFound를 기대했습니다: %v", tt.tool, tt.category, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s/%s: %v", tt.tool, tt.category, err)
+		}
+		if prompt != tt.prompt || origin != tt.origin {
+			t.Errorf("%s/%s: 예상 (%q, %+v), 실제 (%q, %+v)", tt.tool, tt.category, tt.prompt, tt.origin, prompt, origin)
+		}
+	}
+
+	// 도구 목록에는 적용 가능한 공유 프롬프트도 포함
+	origins, err := layers.ListPrompts("claude")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(origins) != 4 || origins["backend"] != (Origin{ScopeUser, SharedTool}) {
+		t.Errorf("claude 프롬프트 목록이 일치하지 않습니다: %+v", origins)
+	}
+
+	all, err := layers.ListAllPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all["claude"]["review"] != ScopeProject || all["claude"]["security"] != ScopeSystem {
+		t.Errorf("전체 목록의 범위가 일치하지 않습니다: %+v", all["claude"])
+	}
+
+	if _, err := layers.Store(ScopeTeam); err == nil {
+		t.Error("설정되지 않은 팀 저장소는 오류를 반환해야 합니다")
+	}
+}
+
+func TestUserDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	// 기본값은 ~/.aide
+	t.Setenv(HomeEnv, "")
+	if dir, err := UserDir(""); err != nil || dir != filepath.Join(tmpDir, StoreDirName) {
+		t.Errorf("기본 저장소 위치가 일치하지 않습니다: %s, %v", dir, err)
+	}
+
+	// $AIDE_HOME이 기본값보다 우선
+	envDir := filepath.Join(tmpDir, "env")
+	t.Setenv(HomeEnv, envDir)
+	if dir, _ := UserDir(""); dir != envDir {
+		t.Errorf("$%s가 적용되지 않았습니다: %s", HomeEnv, dir)
+	}
+
+	// --store가 $AIDE_HOME보다 우선
+	flagDir := filepath.Join(tmpDir, "flag")
+	store, err := New(flagDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.Dir() != flagDir {
+		t.Errorf("--store가 적용되지 않았습니다: %s", store.Dir())
+	}
+	if _, err := os.Stat(flagDir); err != nil {
+		t.Errorf("저장소 디렉터리가 생성되지 않았습니다: %v", err)
+	}
+}
+
+func TestValidateNames(t *testing.T) {
+	valid := []string{"review", "go-errors", "v1.2", "리뷰", "snake_case"}
+	for _, name := range valid {
+		if err := ValidateToolName(name); err != nil {
+			t.Errorf("%q는 올바른 도구 이름이어야 합니다: %v", name, err)
+		}
+		if err := ValidateCategory(name); err != nil {
+			t.Errorf("%q는 올바른 카테고리여야 합니다: %v", name, err)
+		}
+	}
+
+	invalidTools := []string{"", "tools", "Tools", "../x", ".history", "a/b", "@shared", "a b", strings.Repeat("a", 65)}
+	for _, name := range invalidTools {
+		if err := ValidateToolName(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%q는 올바르지 않은 도구 이름이어야 합니다: %v", name, err)
+		}
+	}
+
+	invalidCategories := []string{"", "../../.bashrc", "go/../x", "/abs", "go/", "go//errors", ".hidden", `a\b`, "a/b/c/d/e"}
+	for _, category := range invalidCategories {
+		if err := ValidateCategory(category); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("%q는 올바르지 않은 카테고리여야 합니다: %v", category, err)
+		}
+	}
+}
+
+func TestStorage_NestedCategories(t *testing.T) {
+	// 테스트용 Storage 생성
+	storage := Open(t.TempDir())
+
+	if err := storage.SavePrompt("claude", "go", "Go 규칙"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SavePrompt("claude", "go/errors", "에러 규칙"); err != nil {
+		t.Fatalf("중첩 카테고리 저장 실패: %v", err)
+	}
+	if err := storage.SavePrompt("claude", "../../.bashrc", "x"); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("경로 탈출은 거부되어야 합니다: %v", err)
+	}
+	if err := storage.SaveToolConfig(ToolConfig{Name: "tools", FileName: "x"}); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("예약된 도구 이름은 거부되어야 합니다: %v", err)
+	}
+
+	categories, err := storage.ListPrompts("claude")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(categories)
+	if strings.Join(categories, ",") != "go,go/errors" {
+		t.Errorf("카테고리 목록이 일치하지 않습니다: %v", categories)
+	}
+
+	// 상위 카테고리의 이름을 바꿔도 중첩 카테고리와 그 리비전은 그대로 유지
+	if err := storage.RenamePrompt("claude", "go", "golang", false); err != nil {
+		t.Fatal(err)
+	}
+	if revisions, _ := storage.ListRevisions("claude", "go/errors"); len(revisions) != 1 {
+		t.Errorf("중첩 카테고리의 리비전이 유지되어야 합니다: %+v", revisions)
+	}
+	if revisions, _ := storage.ListRevisions("claude", "golang"); len(revisions) != 1 {
+		t.Errorf("리비전이 옮겨지지 않았습니다: %+v", revisions)
+	}
+
+	// 마지막 중첩 프롬프트를 삭제하면 빈 하위 디렉터리도 정리
+	if err := storage.DeletePrompt("claude", "go/errors"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(storage.Dir(), "claude", "go")); !os.IsNotExist(err) {
+		t.Errorf("빈 하위 디렉터리가 남아 있습니다: %v", err)
+	}
+}
+
+func TestStorage_MigratesLegacyLayout(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// 이전 구조: 최상위에 도구 디렉터리, tools/, .history/
+	files := map[string]string{
+		"claude/review.txt":   "리뷰",
+		"@shared/backend.txt": "공유 백엔드",
+		"tools/vscode.json":   `{"name": "vscode", "fileName": ".vscode/settings.json"}`,
+		".history/claude/review/0001-20250101T000000Z.txt": "리뷰",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 옮기기 전에도 읽을 수 있어야 함
+	legacy := Open(tmpDir)
+	if prompt, err := legacy.GetPrompt("claude", "review"); err != nil || prompt != "리뷰" {
+		t.Fatalf("이전 구조의 프롬프트를 읽을 수 없습니다: %q, %v", prompt, err)
+	}
+
+	store, err := New(tmpDir)
+	if err != nil {
+		t.Fatalf("저장소 생성 실패: %v", err)
+	}
+
+	for _, path := range []string{"store.json", "prompts/claude/review.txt", "prompts/@shared/backend.txt", "history/claude/review", "tools/vscode.json"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, filepath.FromSlash(path))); err != nil {
+			t.Errorf("%s가 없습니다: %v", path, err)
+		}
+	}
+	for _, path := range []string{"claude", ".history", "prompts/tools"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, path)); !os.IsNotExist(err) {
+			t.Errorf("%s가 남아 있습니다", path)
+		}
+	}
+
+	// tools 디렉터리는 도구로 나열되지 않음
+	all, err := store.ListAllPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || len(all["claude"]) != 1 || len(all[SharedTool]) != 1 {
+		t.Errorf("프롬프트 목록이 일치하지 않습니다: %v", all)
+	}
+	if revisions, _ := store.ListRevisions("claude", "review"); len(revisions) != 1 {
+		t.Errorf("리비전 기록이 옮겨지지 않았습니다: %+v", revisions)
+	}
+
+	// 더 새로운 버전의 저장소는 거부
+	os.WriteFile(filepath.Join(tmpDir, "store.json"), []byte(`{"version": 99}`), 0644)
+	if _, err := New(tmpDir); err == nil {
+		t.Error("더 새로운 버전의 저장소는 오류를 반환해야 합니다")
+	}
+}
+
+func TestStorage_PromptMeta(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// 테스트용 Storage 생성
+	storage := &Storage{baseDir: tmpDir}
+
+	// 메타데이터 없이 만든 기존 .txt 프롬프트는 빈 메타데이터로 읽힘
+	legacy := filepath.Join(tmpDir, PromptsDir, "claude", "legacy.txt")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("이전 프롬프트"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta, err := storage.GetMeta("claude", "legacy")
+	if err != nil {
+		t.Fatalf("메타데이터 읽기 실패: %v", err)
+	}
+	if meta.Description != "" || len(meta.Tags) != 0 || !meta.Created.IsZero() {
+		t.Errorf("빈 메타데이터를 기대했습니다: %+v", meta)
+	}
+
+	// 저장하면 생성/수정 시각이 기록됨
+	if err := storage.SavePrompt("claude", "review", "리뷰"); err != nil {
+		t.Fatal(err)
+	}
+	meta, _ = storage.GetMeta("claude", "review")
+	if meta.Created.IsZero() || meta.Updated.IsZero() {
+		t.Errorf("생성/수정 시각이 기록되지 않았습니다: %+v", meta)
+	}
+	created := meta.Created
+
+	// 태그는 정규화되어 저장되고, 다시 저장해도 생성 시각은 유지
+	err = storage.UpdateMeta("claude", "review", func(meta *PromptMeta) {
+		meta.Description = "코드 리뷰"
+		meta.Tags = []string{" Go", "security", "go", ""}
+	})
+	if err != nil {
+		t.Fatalf("메타데이터 저장 실패: %v", err)
+	}
+	if err := storage.SavePrompt("claude", "review", "꼼꼼한 리뷰"); err != nil {
+		t.Fatal(err)
+	}
+	meta, _ = storage.GetMeta("claude", "review")
+	if meta.Description != "코드 리뷰" || strings.Join(meta.Tags, ",") != "Go,security" {
+		t.Errorf("메타데이터가 일치하지 않습니다: %+v", meta)
+	}
+	if !meta.Created.Equal(created) {
+		t.Errorf("생성 시각이 바뀌었습니다: %v -> %v", created, meta.Created)
+	}
+	if !meta.HasTag("GO") {
+		t.Error("태그 비교는 대소문자를 구분하지 않아야 합니다")
+	}
+
+	// 없는 프롬프트의 메타데이터는 저장할 수 없음
+	if err := storage.UpdateMeta("claude", "missing", func(*PromptMeta) {}); err == nil {
+		t.Error("없는 프롬프트의 메타데이터 저장은 오류를 반환해야 합니다")
+	}
+
+	// 복사와 이름 바꾸기는 메타데이터를 함께 옮김
+	if err := storage.CopyPrompt("claude", "review", "cursor", "review", false); err != nil {
+		t.Fatal(err)
+	}
+	if meta, _ := storage.GetMeta("cursor", "review"); meta.Description != "코드 리뷰" {
+		t.Errorf("복사된 메타데이터가 일치하지 않습니다: %+v", meta)
+	}
+	if err := storage.RenamePrompt("claude", "review", "code-review", false); err != nil {
+		t.Fatal(err)
+	}
+	if meta, _ := storage.GetMeta("claude", "code-review"); !meta.HasTag("security") {
+		t.Errorf("이름을 바꾼 프롬프트의 메타데이터가 일치하지 않습니다: %+v", meta)
+	}
+
+	// 삭제하면 메타데이터 파일도 삭제
+	if err := storage.DeletePrompt("claude", "code-review"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(storage.metaPath("claude", "code-review")); !os.IsNotExist(err) {
+		t.Error("삭제된 프롬프트의 메타데이터 파일이 남아 있습니다")
+	}
+}
+
+func TestLayers_ExpandPrompt(t *testing.T) {
+	// 임시 디렉터리 생성
+	tmpDir, err := os.MkdirTemp("", "aide_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	team := Open(filepath.Join(tmpDir, "team"))
+	user := Open(filepath.Join(tmpDir, "user"))
+	layers := &Layers{layers: []Layer{{ScopeTeam, team}, {ScopeUser, user}}}
+
+	team.SavePrompt(SharedTool, "base", "팀 공통 규칙\n")
+	team.SavePrompt(SharedTool, "vue", "<p>{{ msg }}</p>")
+	team.UpdateMeta(SharedTool, "vue", func(meta *PromptMeta) { meta.Raw = true })
+	user.SavePrompt("claude", "review", "{{include \"shared/base\"}}\n리뷰 규칙")
+	user.SavePrompt("claude", "frontend", "{{ include \"@shared/vue\" }}")
+	user.SavePrompt("claude", "backend", "백엔드 규칙")
+	user.UpdateMeta("claude", "backend", func(meta *PromptMeta) { meta.Extends = "claude/review" })
+
+	tests := []struct {
+		category string
+		expected string
+	}{
+		{"review", "팀 공통 규칙\n리뷰 규칙"},             // 다른 범위의 공유 프롬프트 포함
+		{"backend", "팀 공통 규칙\n리뷰 규칙\n\n백엔드 규칙"},  // 부모 프롬프트 상속
+		{"frontend", "<p>{{\"{{\"}} msg }}</p>"}, // --raw 프롬프트는 이스케이프하여 포함
+	}
+	for _, tt := range tests {
+		prompt, origin, err := layers.ExpandPrompt("claude", tt.category)
+		if err != nil {
+			t.Errorf("%s 펼치기 실패: %v", tt.category, err)
+			continue
+		}
+		if prompt != tt.expected {
+			t.Errorf("%s: 예상 %q, 실제 %q", tt.category, tt.expected, prompt)
+		}
+		if origin != (Origin{ScopeUser, "claude"}) {
+			t.Errorf("%s: 찾은 위치가 일치하지 않습니다: %+v", tt.category, origin)
+		}
+	}
+
+	// 순환 참조
+	team.SavePrompt(SharedTool, "base", "{{include \"claude/backend\"}}")
+	if _, _, err := layers.ExpandPrompt("claude", "backend"); err == nil || !strings.Contains(err.Error(), "순환") {
+		t.Errorf("순환 참조 오류를 기대했습니다: %v", err)
+	}
+
+	// 없는 프롬프트와 잘못된 참조
+	user.SavePrompt("claude", "missing", "{{include \"claude/nothing\"}}")
+	user.SavePrompt("claude", "invalid", "{{include \"base\"}}")
+	for _, category := range []string{"missing", "invalid"} {
+		if _, _, err := layers.ExpandPrompt("claude", category); err == nil {
+			t.Errorf("%s: 오류를 반환해야 합니다", category)
+		}
+	}
+}
+
+func TestFindProjectDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// 기본 사용자 저장소(~/.aide)와 프로젝트 저장소
+	if err := os.MkdirAll(filepath.Join(home, StoreDirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	project := filepath.Join(home, "work", "api")
+	if err := os.MkdirAll(filepath.Join(project, StoreDirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	scratch := filepath.Join(home, "scratch", "sub")
+	if err := os.MkdirAll(scratch, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// $AIDE_HOME으로 다른 사용자 저장소를 지정해도 ~/.aide는 프로젝트 저장소가 아님
+	userDir := filepath.Join(t.TempDir(), "store")
+	t.Setenv(HomeEnv, userDir)
+
+	if dir := FindProjectDir(scratch, userDir); dir != "" {
+		t.Errorf("~/.aide를 프로젝트 저장소로 찾으면 안 됩니다: %s", dir)
+	}
+	if dir := FindProjectDir(filepath.Join(project, "cmd"), userDir); dir != project {
+		t.Errorf("프로젝트 저장소를 찾지 못했습니다: %s", dir)
+	}
+
+	// 사용자 저장소로 지정한 디렉터리도 프로젝트 저장소가 아님
+	if dir := FindProjectDir(filepath.Join(project, "cmd"), filepath.Join(project, StoreDirName)); dir != "" {
+		t.Errorf("사용자 저장소를 프로젝트 저장소로 찾으면 안 됩니다: %s", dir)
+	}
+}
@@ -0,0 +1,171 @@
+package tree
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseJSON은 JSON 객체를 키 순서를 보존하여 파싱합니다
+func ParseJSON(data string) (*Map, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("올바른 JSON이 아닙니다: %w", err)
+	}
+
+	m, ok := value.(*Map)
+	if !ok {
+		return nil, fmt.Errorf("JSON 최상위 값은 객체여야 합니다")
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("올바른 JSON이 아닙니다: 객체 뒤에 추가 내용이 있습니다")
+	}
+
+	return m, nil
+}
+
+// decodeJSONValue는 토큰 스트림에서 값 하나를 읽습니다
+func decodeJSONValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			m := NewMap()
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyToken.(string)
+				if !ok {
+					return nil, fmt.Errorf("객체 키가 문자열이 아닙니다: %v", keyToken)
+				}
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				m.Set(key, value)
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			return m, nil
+		case '[':
+			list := []any{}
+			for decoder.More() {
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			return list, nil
+		default:
+			return nil, fmt.Errorf("예상하지 못한 구분자입니다: %v", t)
+		}
+	case json.Number:
+		return Number(t), nil
+	default:
+		// string, bool, nil
+		return t, nil
+	}
+}
+
+// EncodeJSON은 Map을 들여쓰기된 JSON으로 변환합니다
+func EncodeJSON(m *Map, indent string) string {
+	var out strings.Builder
+	writeJSONValue(&out, m, indent, 0)
+	out.WriteString("\n")
+	return out.String()
+}
+
+// DetectJSONIndent는 기존 JSON 문서에서 사용한 들여쓰기를 찾습니다. 찾지 못하면 공백 두 칸을 반환합니다.
+func DetectJSONIndent(data string) string {
+	for _, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "  "
+}
+
+// writeJSONValue는 값 하나를 JSON으로 출력합니다
+func writeJSONValue(out *strings.Builder, value any, indent string, depth int) {
+	switch v := value.(type) {
+	case *Map:
+		if v.Len() == 0 {
+			out.WriteString("{}")
+			return
+		}
+		out.WriteString("{\n")
+		for i, key := range v.keys {
+			out.WriteString(strings.Repeat(indent, depth+1))
+			out.WriteString(quoteJSON(key))
+			out.WriteString(": ")
+			writeJSONValue(out, v.values[key], indent, depth+1)
+			if i < len(v.keys)-1 {
+				out.WriteString(",")
+			}
+			out.WriteString("\n")
+		}
+		out.WriteString(strings.Repeat(indent, depth))
+		out.WriteString("}")
+	case []any:
+		if len(v) == 0 {
+			out.WriteString("[]")
+			return
+		}
+		out.WriteString("[\n")
+		for i, item := range v {
+			out.WriteString(strings.Repeat(indent, depth+1))
+			writeJSONValue(out, item, indent, depth+1)
+			if i < len(v)-1 {
+				out.WriteString(",")
+			}
+			out.WriteString("\n")
+		}
+		out.WriteString(strings.Repeat(indent, depth))
+		out.WriteString("]")
+	case string:
+		out.WriteString(quoteJSON(v))
+	case Number:
+		out.WriteString(string(v))
+	case bool:
+		if v {
+			out.WriteString("true")
+		} else {
+			out.WriteString("false")
+		}
+	case nil:
+		out.WriteString("null")
+	default:
+		out.WriteString(quoteJSON(fmt.Sprint(v)))
+	}
+}
+
+// quoteJSON은 HTML 이스케이프 없이 문자열을 JSON 문자열로 변환합니다
+func quoteJSON(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,90 @@
+package tree
+
+import (
+	"sort"
+	"strings"
+)
+
+// Originals는 키 경로별로 문서에 쓰였던 원본 텍스트입니다 (YAMLSource, TOMLSource로 얻음).
+// PatchYAML과 PatchTOML은 값을 다시 써야 할 때 원본 텍스트가 같은 값을 나타내면 새로 출력하는 대신
+// 원본 텍스트를 그대로 써서, 되돌린 값의 따옴표나 빈 값 표기까지 원래대로 유지합니다.
+type Originals map[string]string
+
+// Set은 키 경로의 원본 텍스트를 기록합니다
+func (o Originals) Set(path []string, text string) {
+	o[strings.Join(path, "\x00")] = text
+}
+
+// get은 키 경로의 원본 텍스트를 찾습니다
+func (o Originals) get(path []string) (string, bool) {
+	text, ok := o[strings.Join(path, "\x00")]
+	return text, ok
+}
+
+// sourceEditor는 원본 문서의 바뀐 부분만 고쳐 쓰기 위한 편집 목록입니다.
+// 편집하지 않은 부분은 주석과 서식을 포함해 원본 그대로 유지됩니다.
+type sourceEditor struct {
+	src   string
+	edits []textEdit
+}
+
+// textEdit는 원본의 [start, end) 구간을 text로 바꾸는 편집입니다 (start == end이면 삽입)
+type textEdit struct {
+	start, end int
+	text       string
+	seq        int
+}
+
+// replace는 원본의 구간을 text로 바꿉니다
+func (e *sourceEditor) replace(start, end int, text string) {
+	e.edits = append(e.edits, textEdit{start: start, end: end, text: text, seq: len(e.edits)})
+}
+
+// insert는 원본의 위치에 줄 단위 text를 삽입합니다. 같은 위치의 삽입은 호출 순서대로 놓입니다.
+// 줄바꿈으로 끝나지 않는 문서의 끝에 삽입하면 줄바꿈을 먼저 넣습니다.
+func (e *sourceEditor) insert(at int, text string) {
+	if at == len(e.src) && e.src != "" && !strings.HasSuffix(e.src, "\n") {
+		text = "\n" + text
+	}
+	e.replace(at, at, text)
+}
+
+// String은 편집을 반영한 문서를 반환합니다. 편집 구간은 서로 겹치지 않아야 합니다.
+func (e *sourceEditor) String() string {
+	edits := append([]textEdit{}, e.edits...)
+	sort.Slice(edits, func(i, j int) bool {
+		a, b := edits[i], edits[j]
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		if a.end != b.end {
+			return a.end < b.end // 같은 위치에서는 삽입이 먼저
+		}
+		return a.seq < b.seq
+	})
+
+	var out strings.Builder
+	cursor := 0
+	for _, edit := range edits {
+		if edit.start > cursor {
+			out.WriteString(e.src[cursor:edit.start])
+		}
+		out.WriteString(edit.text)
+		if edit.end > cursor {
+			cursor = edit.end
+		}
+	}
+	out.WriteString(e.src[cursor:])
+	return out.String()
+}
+
+// lineOffsets는 각 줄의 시작 위치를 반환합니다. 마지막 항목은 문서 끝입니다.
+func lineOffsets(lines []string) []int {
+	offsets := make([]int, len(lines)+1)
+	for i, line := range lines {
+		offsets[i+1] = offsets[i] + len(line) + 1
+	}
+	// 마지막 줄 뒤에는 줄바꿈이 없음
+	offsets[len(lines)]--
+	return offsets
+}
@@ -0,0 +1,943 @@
+package tree
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TOML은 키/값, 점으로 구분된 키, [테이블], [[테이블 배열]], 인라인 테이블, 배열과
+// 모든 문자열 형식을 지원합니다. 숫자와 날짜는 원본 표기 그대로 보존합니다.
+// 기존 문서를 고칠 때는 PatchTOML로 바뀐 키만 다시 써서 주석과 서식을 유지합니다.
+
+// tomlBareKey는 따옴표 없이 쓸 수 있는 키입니다
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// tomlParser는 문자 단위 TOML 파서입니다
+type tomlParser struct {
+	s    string
+	pos  int
+	line int
+
+	// statements는 머리글과 키/값 줄의 원본 위치입니다 (PatchTOML에서만 기록)
+	statements []*tomlStatement
+	record     bool
+	// valueStart, valueEnd는 마지막으로 읽은 키/값의 키 경로와 값 위치입니다
+	keyPath              []string
+	valueStart, valueEnd int
+}
+
+// tomlStatement는 머리글 또는 최상위 키/값 한 줄(여러 줄 값 포함)의 원본 위치입니다
+type tomlStatement struct {
+	start, end int  // 줄 시작부터 줄바꿈 다음까지
+	table      *Map // 머리글이면 정의한 테이블, 키/값이면 속한 테이블
+	header     bool
+
+	// 키/값일 때 테이블 기준 키 경로와 값의 위치
+	keyPath              []string
+	valueStart, valueEnd int
+}
+
+// ParseTOML은 TOML 문서를 키 순서를 보존하여 파싱합니다
+func ParseTOML(data string) (*Map, error) {
+	return parseTOML(&tomlParser{s: strings.ReplaceAll(data, "\r\n", "\n"), line: 1})
+}
+
+// parseTOML은 파서의 내용을 문서 하나로 파싱합니다
+func parseTOML(p *tomlParser) (*Map, error) {
+	root := NewMap()
+	if err := p.parse(root); err != nil {
+		return nil, fmt.Errorf("올바른 TOML이 아닙니다: %d번째 줄: %w", p.line, err)
+	}
+	return root, nil
+}
+
+// parse는 문서 전체를 읽어 root에 채웁니다
+func (p *tomlParser) parse(root *Map) error {
+	current := root
+	for {
+		p.skipTrivia(true)
+		if p.eof() {
+			return nil
+		}
+
+		statement := &tomlStatement{start: strings.LastIndexByte(p.s[:p.pos], '\n') + 1, table: current}
+		if p.peek() == '[' {
+			table, err := p.parseHeader(root)
+			if err != nil {
+				return err
+			}
+			current = table
+			statement.table, statement.header = table, true
+		} else {
+			if err := p.parseKeyValue(current); err != nil {
+				return err
+			}
+			statement.keyPath, statement.valueStart, statement.valueEnd = p.keyPath, p.valueStart, p.valueEnd
+		}
+
+		if err := p.expectLineEnd(); err != nil {
+			return err
+		}
+		if p.record {
+			statement.end = p.pos
+			p.statements = append(p.statements, statement)
+		}
+	}
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *tomlParser) peek() byte {
+	return p.s[p.pos]
+}
+
+func (p *tomlParser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.s[p.pos:], prefix)
+}
+
+// advance는 n글자를 건너뛰며 줄 번호를 갱신합니다
+func (p *tomlParser) advance(n int) {
+	p.line += strings.Count(p.s[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// skipTrivia는 공백과 주석을 건너뜁니다. newlines가 true이면 줄바꿈도 건너뜁니다.
+func (p *tomlParser) skipTrivia(newlines bool) {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t':
+			p.advance(1)
+		case c == '\n' && newlines:
+			p.advance(1)
+		case c == '#':
+			end := strings.IndexByte(p.s[p.pos:], '\n')
+			if end < 0 {
+				end = len(p.s) - p.pos
+			}
+			p.advance(end)
+		default:
+			return
+		}
+	}
+}
+
+// expectLineEnd는 줄 끝(또는 주석, 파일 끝)인지 확인합니다
+func (p *tomlParser) expectLineEnd() error {
+	p.skipTrivia(false)
+	if p.eof() {
+		return nil
+	}
+	if p.peek() != '\n' {
+		return fmt.Errorf("줄 끝에 예상하지 못한 내용이 있습니다: %q", p.rest())
+	}
+	p.advance(1)
+	return nil
+}
+
+// rest는 오류 메시지용으로 현재 줄의 남은 내용을 반환합니다
+func (p *tomlParser) rest() string {
+	rest := p.s[p.pos:]
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		rest = rest[:end]
+	}
+	return rest
+}
+
+// parseHeader는 [테이블] 또는 [[테이블 배열]] 머리글을 읽고 대상 테이블을 반환합니다
+func (p *tomlParser) parseHeader(root *Map) (*Map, error) {
+	array := p.hasPrefix("[[")
+	if array {
+		p.advance(2)
+	} else {
+		p.advance(1)
+	}
+
+	path, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipTrivia(false)
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !p.hasPrefix(closing) {
+		return nil, fmt.Errorf("테이블 머리글이 닫히지 않았습니다")
+	}
+	p.advance(len(closing))
+
+	parent, err := tomlTable(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	if array {
+		existing, _ := parent.Get(last)
+		list, ok := existing.([]any)
+		if existing != nil && !ok {
+			return nil, fmt.Errorf("'%s'는 테이블 배열이 아닙니다", strings.Join(path, "."))
+		}
+		table := NewMap()
+		parent.Set(last, append(list, table))
+		return table, nil
+	}
+
+	return tomlTable(parent, []string{last})
+}
+
+// tomlTable은 경로를 따라 테이블을 찾거나 만듭니다. 테이블 배열은 마지막 항목을 따라갑니다.
+func tomlTable(m *Map, path []string) (*Map, error) {
+	for _, key := range path {
+		value, ok := m.Get(key)
+		if !ok {
+			child := NewMap()
+			m.Set(key, child)
+			m = child
+			continue
+		}
+		switch v := value.(type) {
+		case *Map:
+			m = v
+		case []any:
+			if len(v) == 0 {
+				return nil, fmt.Errorf("'%s'는 테이블이 아닙니다", key)
+			}
+			last, ok := v[len(v)-1].(*Map)
+			if !ok {
+				return nil, fmt.Errorf("'%s'는 테이블이 아닙니다", key)
+			}
+			m = last
+		default:
+			return nil, fmt.Errorf("'%s'는 테이블이 아닙니다", key)
+		}
+	}
+	return m, nil
+}
+
+// parseKeyValue는 "키 = 값" 한 쌍을 읽어 table에 설정합니다
+func (p *tomlParser) parseKeyValue(table *Map) error {
+	path, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	p.skipTrivia(false)
+	if p.eof() || p.peek() != '=' {
+		return fmt.Errorf("키 뒤에 '='가 없습니다: %q", p.rest())
+	}
+	p.advance(1)
+	p.skipTrivia(false)
+
+	start := p.pos
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+	p.keyPath, p.valueStart, p.valueEnd = path, start, p.pos
+
+	parent, err := tomlTable(table, path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	last := path[len(path)-1]
+	if _, exists := parent.Get(last); exists {
+		return fmt.Errorf("키 '%s'가 중복되었습니다", strings.Join(path, "."))
+	}
+	parent.Set(last, value)
+	return nil
+}
+
+// parseKey는 점으로 구분된 키를 읽습니다
+func (p *tomlParser) parseKey() ([]string, error) {
+	var path []string
+	for {
+		p.skipTrivia(false)
+		if p.eof() {
+			return nil, fmt.Errorf("키가 없습니다")
+		}
+
+		var key string
+		switch p.peek() {
+		case '"', '\'':
+			value, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			key = value
+		default:
+			start := p.pos
+			for !p.eof() && tomlBareKey.MatchString(string(p.peek())) {
+				p.advance(1)
+			}
+			if start == p.pos {
+				return nil, fmt.Errorf("잘못된 키입니다: %q", p.rest())
+			}
+			key = p.s[start:p.pos]
+		}
+		path = append(path, key)
+
+		p.skipTrivia(false)
+		if p.eof() || p.peek() != '.' {
+			return path, nil
+		}
+		p.advance(1)
+	}
+}
+
+// parseValue는 값 하나를 읽습니다
+func (p *tomlParser) parseValue() (any, error) {
+	if p.eof() {
+		return nil, fmt.Errorf("값이 없습니다")
+	}
+
+	switch p.peek() {
+	case '"', '\'':
+		return p.parseString()
+	case '[':
+		return p.parseArray()
+	case '{':
+		return p.parseInlineTable()
+	}
+
+	// 불리언, 숫자, 날짜
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\n,]}#", rune(p.peek())) {
+		p.advance(1)
+	}
+	// "1979-05-27 07:32:00"처럼 공백으로 날짜와 시간을 구분한 경우
+	if token := p.s[start:p.pos]; len(token) == 10 && strings.Count(token, "-") == 2 && p.hasPrefix(" ") &&
+		p.pos+3 < len(p.s) && p.s[p.pos+3] == ':' {
+		p.advance(1)
+		for !p.eof() && !strings.ContainsRune(" \t\n,]}#", rune(p.peek())) {
+			p.advance(1)
+		}
+	}
+
+	token := p.s[start:p.pos]
+	switch token {
+	case "":
+		return nil, fmt.Errorf("값이 없습니다: %q", p.rest())
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if c := token[0]; !(c >= '0' && c <= '9') && c != '+' && c != '-' && token != "inf" && token != "nan" {
+		return nil, fmt.Errorf("잘못된 값입니다: %s", token)
+	}
+	return Number(token), nil
+}
+
+// parseString은 네 가지 TOML 문자열 형식 중 하나를 읽습니다
+func (p *tomlParser) parseString() (string, error) {
+	switch {
+	case p.hasPrefix(`"""`):
+		return p.parseMultiline(`"""`, true)
+	case p.hasPrefix("'''"):
+		return p.parseMultiline("'''", false)
+	case p.peek() == '\'':
+		end := strings.IndexAny(p.s[p.pos+1:], "'\n")
+		if end < 0 || p.s[p.pos+1+end] != '\'' {
+			return "", fmt.Errorf("문자열이 닫히지 않았습니다")
+		}
+		value := p.s[p.pos+1 : p.pos+1+end]
+		p.advance(end + 2)
+		return value, nil
+	default:
+		for i := p.pos + 1; i < len(p.s); i++ {
+			switch p.s[i] {
+			case '\\':
+				i++
+			case '\n':
+				return "", fmt.Errorf("문자열이 닫히지 않았습니다")
+			case '"':
+				value, err := unescapeTOML(p.s[p.pos+1 : i])
+				if err != nil {
+					return "", err
+				}
+				p.advance(i + 1 - p.pos)
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("문자열이 닫히지 않았습니다")
+	}
+}
+
+// parseMultiline은 여러 줄 문자열을 읽습니다
+func (p *tomlParser) parseMultiline(delim string, basic bool) (string, error) {
+	p.advance(len(delim))
+	body := p.s[p.pos:]
+
+	end := -1
+	for i := 0; i+len(delim) <= len(body); i++ {
+		if basic && body[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(body[i:], delim) {
+			end = i
+			// 닫는 구분자 바로 앞의 따옴표는 내용에 포함
+			for end+len(delim) < len(body) && body[end+len(delim)] == delim[0] {
+				end++
+			}
+			break
+		}
+	}
+	if end < 0 {
+		return "", fmt.Errorf("여러 줄 문자열이 닫히지 않았습니다")
+	}
+
+	value := body[:end]
+	p.advance(end + len(delim))
+
+	// 여는 구분자 바로 뒤의 줄바꿈은 제외
+	value = strings.TrimPrefix(value, "\n")
+	if !basic {
+		return value, nil
+	}
+
+	// 줄 끝 역슬래시는 다음 공백까지 이어 붙이기
+	var out strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			j := i + 1
+			for j < len(value) && (value[j] == ' ' || value[j] == '\t') {
+				j++
+			}
+			if j < len(value) && value[j] == '\n' {
+				for j < len(value) && strings.ContainsRune(" \t\n", rune(value[j])) {
+					j++
+				}
+				i = j - 1
+				continue
+			}
+		}
+		out.WriteByte(value[i])
+	}
+	return unescapeTOML(out.String())
+}
+
+// unescapeTOML은 기본 문자열의 이스케이프를 해석합니다
+func unescapeTOML(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			out.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", fmt.Errorf("잘못된 이스케이프입니다")
+		}
+		switch s[i] {
+		case 'b':
+			out.WriteByte('\b')
+		case 't':
+			out.WriteByte('\t')
+		case 'n':
+			out.WriteByte('\n')
+		case 'f':
+			out.WriteByte('\f')
+		case 'r':
+			out.WriteByte('\r')
+		case 'e':
+			out.WriteByte(0x1b)
+		case '"', '\\':
+			out.WriteByte(s[i])
+		case 'u', 'U':
+			size := 4
+			if s[i] == 'U' {
+				size = 8
+			}
+			if i+size >= len(s) {
+				return "", fmt.Errorf("잘못된 유니코드 이스케이프입니다")
+			}
+			var r rune
+			if _, err := fmt.Sscanf(s[i+1:i+1+size], "%x", &r); err != nil {
+				return "", fmt.Errorf("잘못된 유니코드 이스케이프입니다: %w", err)
+			}
+			out.WriteRune(r)
+			i += size
+		default:
+			return "", fmt.Errorf("잘못된 이스케이프입니다: \\%c", s[i])
+		}
+	}
+	return out.String(), nil
+}
+
+// parseArray는 배열을 읽습니다. 줄바꿈과 주석, 마지막 쉼표를 허용합니다.
+func (p *tomlParser) parseArray() ([]any, error) {
+	p.advance(1)
+	list := []any{}
+	for {
+		p.skipTrivia(true)
+		if p.eof() {
+			return nil, fmt.Errorf("배열이 닫히지 않았습니다")
+		}
+		if p.peek() == ']' {
+			p.advance(1)
+			return list, nil
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+
+		p.skipTrivia(true)
+		if p.eof() {
+			return nil, fmt.Errorf("배열이 닫히지 않았습니다")
+		}
+		switch p.peek() {
+		case ',':
+			p.advance(1)
+		case ']':
+		default:
+			return nil, fmt.Errorf("배열에 예상하지 못한 내용이 있습니다: %q", p.rest())
+		}
+	}
+}
+
+// parseInlineTable은 { 키 = 값, ... } 형태의 인라인 테이블을 읽습니다
+func (p *tomlParser) parseInlineTable() (*Map, error) {
+	p.advance(1)
+	table := NewMap()
+	for {
+		p.skipTrivia(false)
+		if p.eof() {
+			return nil, fmt.Errorf("인라인 테이블이 닫히지 않았습니다")
+		}
+		if p.peek() == '}' {
+			p.advance(1)
+			return table, nil
+		}
+
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+
+		p.skipTrivia(false)
+		if p.eof() {
+			return nil, fmt.Errorf("인라인 테이블이 닫히지 않았습니다")
+		}
+		switch p.peek() {
+		case ',':
+			p.advance(1)
+		case '}':
+		default:
+			return nil, fmt.Errorf("인라인 테이블에 예상하지 못한 내용이 있습니다: %q", p.rest())
+		}
+	}
+}
+
+// EncodeTOML은 Map을 TOML 문서로 변환합니다. TOML에는 null이 없으므로 null 값은 오류입니다.
+func EncodeTOML(m *Map) (string, error) {
+	var out strings.Builder
+	if err := writeTOMLTable(&out, m, nil); err != nil {
+		return "", err
+	}
+	return strings.TrimLeft(out.String(), "\n"), nil
+}
+
+// isTableArray는 값이 비어 있지 않은 테이블 배열인지 확인합니다
+func isTableArray(value any) bool {
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(*Map); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// writeTOMLTable은 테이블의 키/값을 먼저 출력하고, 하위 테이블을 머리글과 함께 출력합니다
+func writeTOMLTable(out *strings.Builder, m *Map, path []string) error {
+	for _, key := range m.keys {
+		value := m.values[key]
+		if _, ok := value.(*Map); ok || isTableArray(value) {
+			continue
+		}
+		encoded, err := formatTOMLValue(value)
+		if err != nil {
+			return fmt.Errorf("'%s': %w", strings.Join(append(path, key), "."), err)
+		}
+		out.WriteString(quoteTOMLKey(key) + " = " + encoded + "\n")
+	}
+
+	for _, key := range m.keys {
+		childPath := append(append([]string{}, path...), key)
+		switch value := m.values[key].(type) {
+		case *Map:
+			out.WriteString("\n[" + tomlPath(childPath) + "]\n")
+			if err := writeTOMLTable(out, value, childPath); err != nil {
+				return err
+			}
+		case []any:
+			if !isTableArray(value) {
+				continue
+			}
+			for _, item := range value {
+				out.WriteString("\n[[" + tomlPath(childPath) + "]]\n")
+				if err := writeTOMLTable(out, item.(*Map), childPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// formatTOMLValue는 인라인 값을 TOML 표기로 변환합니다
+func formatTOMLValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", fmt.Errorf("TOML은 null 값을 지원하지 않습니다")
+	case bool:
+		if v {
+			return "true", nil
+		}
+		return "false", nil
+	case Number:
+		return string(v), nil
+	case string:
+		if strings.Contains(v, "\n") && !strings.Contains(v, "'''") && !strings.ContainsAny(v, "\r\x7f") {
+			return "'''\n" + v + "'''", nil
+		}
+		return quoteJSON(v), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			encoded, err := formatTOMLValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, encoded)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case *Map:
+		items := make([]string, 0, v.Len())
+		for _, key := range v.keys {
+			encoded, err := formatTOMLValue(v.values[key])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, quoteTOMLKey(key)+" = "+encoded)
+		}
+		if len(items) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	default:
+		return quoteJSON(fmt.Sprint(v)), nil
+	}
+}
+
+// quoteTOMLKey는 필요한 경우 키를 따옴표로 감쌉니다
+func quoteTOMLKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return quoteJSON(key)
+}
+
+// tomlPath는 테이블 머리글에 쓸 점 경로를 만듭니다
+func tomlPath(path []string) string {
+	quoted := make([]string, len(path))
+	for i, key := range path {
+		quoted[i] = quoteTOMLKey(key)
+	}
+	return strings.Join(quoted, ".")
+}
+
+// PatchTOML은 기존 TOML 문서 source를 doc과 같은 값이 되도록 고칩니다.
+// 바뀐 키만 다시 쓰고 나머지 줄은 그대로 두므로 주석과 서식이 유지됩니다.
+// 키/값 줄의 값은 그 자리에서 바꾸고, 새 키는 테이블의 마지막 키/값 뒤에, 새 테이블은 문서 끝에 추가합니다.
+// 머리글로 정의한 테이블 배열이 바뀌면 통째로 지우고 문서 끝에 다시 씁니다.
+// originals에 바뀐 키의 원본 값 텍스트가 있고 같은 값을 나타내면 그 텍스트로 되돌립니다.
+func PatchTOML(source string, doc *Map, originals Originals) (string, error) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	if strings.TrimSpace(source) == "" {
+		return EncodeTOML(doc)
+	}
+
+	p := &tomlParser{s: source, line: 1, record: true}
+	orig, err := parseTOML(p)
+	if err != nil {
+		return "", err
+	}
+
+	e := &tomlPatcher{editor: &sourceEditor{src: source}, statements: p.statements, deleted: make(map[*tomlStatement]bool), originals: originals}
+	if err := e.patchTable(orig, doc, orig, nil, nil); err != nil {
+		return "", err
+	}
+	output := e.editor.String()
+	if e.appended.Len() > 0 {
+		// 새 테이블은 빈 줄 하나를 두고 문서 끝에 추가
+		output = strings.TrimLeft(strings.TrimRight(output, "\n")+"\n"+e.appended.String(), "\n")
+	}
+	return output, nil
+}
+
+// TOMLSource는 문서 source에서 path 키를 정의한 키/값 줄의 원본 값 텍스트를 반환합니다.
+// 테이블 배열 안의 키와 머리글로 정의한 테이블은 찾을 수 없습니다.
+func TOMLSource(source string, path []string) (string, bool) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	p := &tomlParser{s: source, line: 1, record: true}
+	root, err := parseTOML(p)
+	if err != nil {
+		return "", false
+	}
+
+	tables := make(map[*Map][]string)
+	collectTablePaths(root, nil, tables)
+	for _, s := range p.statements {
+		table, ok := tables[s.table]
+		if s.header || !ok {
+			continue
+		}
+		full := append(append([]string{}, table...), s.keyPath...)
+		if len(full) == len(path) && samePrefix(full, path) {
+			return source[s.valueStart:s.valueEnd], true
+		}
+	}
+	return "", false
+}
+
+// collectTablePaths는 루트부터 테이블 배열을 거치지 않고 닿는 테이블마다 키 경로를 모읍니다
+func collectTablePaths(table *Map, path []string, paths map[*Map][]string) {
+	paths[table] = path
+	for _, key := range table.keys {
+		if child, ok := table.values[key].(*Map); ok {
+			collectTablePaths(child, append(append([]string{}, path...), key), paths)
+		}
+	}
+}
+
+// tomlPatcher는 원본 위치를 따라 TOML 문서를 고칩니다
+type tomlPatcher struct {
+	editor     *sourceEditor
+	statements []*tomlStatement
+	deleted    map[*tomlStatement]bool
+	appended   strings.Builder // 문서 끝에 추가할 테이블
+	originals  Originals
+}
+
+// patchTable은 원본 테이블 orig를 doc과 같아지도록 고칩니다.
+// orig의 키는 owner 테이블(머리글로 정의한 테이블 또는 루트)의 키/값 줄에서 rel 경로 아래에 정의되며,
+// path는 문서 루트부터의 전체 경로입니다.
+func (e *tomlPatcher) patchTable(orig, doc, owner *Map, rel, path []string) error {
+	var added []string
+	for _, key := range orig.keys {
+		value, ok := doc.values[key]
+		current := orig.values[key]
+		switch {
+		case ok && Equal(current, value):
+			continue
+		case !ok:
+			e.remove(owner, rel, key, current)
+			continue
+		}
+
+		keyRel := append(append([]string{}, rel...), key)
+		keyPath := append(append([]string{}, path...), key)
+
+		// 키/값 줄 하나로 정의된 값은 그 자리에서 바꿈
+		if defs := e.definitions(owner, rel, key); len(defs) == 1 && len(defs[0].keyPath) == len(keyRel) {
+			encoded, ok := e.original(keyPath, value)
+			if !ok {
+				var err error
+				if encoded, err = formatTOMLValue(value); err != nil {
+					return fmt.Errorf("'%s': %w", strings.Join(keyPath, "."), err)
+				}
+			}
+			e.editor.replace(defs[0].valueStart, defs[0].valueEnd, encoded)
+			continue
+		}
+
+		// 양쪽 모두 테이블이면 안쪽 키만 고침
+		origChild, isMap := current.(*Map)
+		docChild, docIsMap := value.(*Map)
+		if isMap && docIsMap {
+			childOwner, childRel := owner, keyRel
+			if e.header(origChild) != nil {
+				childOwner, childRel = origChild, nil
+			}
+			if err := e.patchTable(origChild, docChild, childOwner, childRel, keyPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		e.remove(owner, rel, key, current)
+		added = append(added, key)
+	}
+	for _, key := range doc.keys {
+		if _, ok := orig.values[key]; !ok {
+			added = append(added, key)
+		}
+	}
+
+	// 새 키 추가: 값은 테이블의 키/값 뒤에, 테이블과 테이블 배열은 문서 끝에
+	var values strings.Builder
+	for _, key := range added {
+		value := doc.values[key]
+		if _, ok := value.(*Map); ok || isTableArray(value) {
+			section := NewMap()
+			section.Set(key, value)
+			if err := writeTOMLTable(&e.appended, section, path); err != nil {
+				return err
+			}
+			continue
+		}
+		encoded, err := formatTOMLValue(value)
+		if err != nil {
+			return fmt.Errorf("'%s': %w", strings.Join(append(append([]string{}, path...), key), "."), err)
+		}
+		values.WriteString(tomlPath(append(append([]string{}, rel...), key)) + " = " + encoded + "\n")
+	}
+	if values.Len() > 0 {
+		e.insertValues(owner, values.String())
+	}
+	return nil
+}
+
+// original은 path 키의 원본 값 텍스트가 value를 나타내면 그 텍스트를 반환합니다
+func (e *tomlPatcher) original(path []string, value any) (string, bool) {
+	text, ok := e.originals.get(path)
+	if !ok {
+		return "", false
+	}
+	m, err := ParseTOML("v = " + text)
+	if err != nil || m.Len() != 1 || !Equal(m.values["v"], value) {
+		return "", false
+	}
+	return text, true
+}
+
+// definitions는 owner 테이블의 키/값 줄 중에서 rel 경로 아래의 key를 정의하는 줄을 찾습니다
+func (e *tomlPatcher) definitions(owner *Map, rel []string, key string) []*tomlStatement {
+	var defs []*tomlStatement
+	for _, s := range e.statements {
+		if s.header || s.table != owner || len(s.keyPath) <= len(rel) || s.keyPath[len(rel)] != key {
+			continue
+		}
+		if samePrefix(s.keyPath, rel) {
+			defs = append(defs, s)
+		}
+	}
+	return defs
+}
+
+// header는 테이블을 정의한 머리글 줄을 찾습니다 (없으면 nil)
+func (e *tomlPatcher) header(table *Map) *tomlStatement {
+	for _, s := range e.statements {
+		if s.header && s.table == table {
+			return s
+		}
+	}
+	return nil
+}
+
+// remove는 key를 정의한 키/값 줄과, 값 아래의 테이블을 정의한 머리글 및 그 키/값 줄을 모두 지웁니다
+func (e *tomlPatcher) remove(owner *Map, rel []string, key string, value any) {
+	tables := make(map[*Map]bool)
+	collectTables(value, tables)
+
+	for _, s := range e.definitions(owner, rel, key) {
+		e.delete(s)
+	}
+	for _, s := range e.statements {
+		if tables[s.table] {
+			e.delete(s)
+		}
+	}
+}
+
+// delete는 줄 하나를 한 번만 지웁니다. 머리글은 앞의 빈 줄도 함께 지웁니다.
+func (e *tomlPatcher) delete(s *tomlStatement) {
+	if e.deleted[s] {
+		return
+	}
+	e.deleted[s] = true
+
+	start := s.start
+	for s.header && start > 0 && strings.HasSuffix(e.editor.src[:start], "\n\n") {
+		start--
+	}
+	e.editor.replace(start, s.end, "")
+}
+
+// insertValues는 owner 테이블의 마지막 키/값 줄 뒤에 text를 삽입합니다.
+// 키/값이 없으면 머리글 바로 뒤에, 루트이면 첫 머리글 앞의 주석 묶음 앞에 삽입합니다.
+func (e *tomlPatcher) insertValues(owner *Map, text string) {
+	var last, header *tomlStatement
+	for _, s := range e.statements {
+		switch {
+		case s.table != owner:
+		case s.header:
+			header = s
+		default:
+			last = s
+		}
+	}
+
+	switch {
+	case last != nil:
+		e.editor.insert(last.end, text)
+	case header != nil:
+		e.editor.insert(header.end, text)
+	case len(e.statements) == 0:
+		e.editor.insert(len(e.editor.src), text)
+	default:
+		// 첫 머리글에 붙은 주석은 머리글과 함께 두도록 그 앞에 삽입
+		at := e.statements[0].start
+		for at > 0 {
+			prev := strings.LastIndexByte(e.editor.src[:at-1], '\n') + 1
+			if !strings.HasPrefix(strings.TrimSpace(e.editor.src[prev:at-1]), "#") {
+				break
+			}
+			at = prev
+		}
+		e.editor.insert(at, text+"\n")
+	}
+}
+
+// collectTables는 값 아래의 모든 테이블을 모읍니다
+func collectTables(value any, tables map[*Map]bool) {
+	switch v := value.(type) {
+	case *Map:
+		tables[v] = true
+		for _, key := range v.keys {
+			collectTables(v.values[key], tables)
+		}
+	case []any:
+		for _, item := range v {
+			collectTables(item, tables)
+		}
+	}
+}
+
+// samePrefix는 path가 prefix로 시작하는지 확인합니다
+func samePrefix(path, prefix []string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+package tree
+
+// Map은 키 순서를 보존하는 설정 객체입니다.
+// 값은 *Map, []any, string, Number, bool, nil 중 하나입니다.
+type Map struct {
+	keys   []string
+	values map[string]any
+}
+
+// Number는 원본 표기를 그대로 보존하는 숫자 값입니다
+type Number string
+
+// NewMap은 빈 Map을 생성합니다
+func NewMap() *Map {
+	return &Map{values: make(map[string]any)}
+}
+
+// Keys는 키 목록을 순서대로 반환합니다
+func (m *Map) Keys() []string {
+	return m.keys
+}
+
+// Len은 키 개수를 반환합니다
+func (m *Map) Len() int {
+	return len(m.keys)
+}
+
+// Get은 키에 해당하는 값을 반환합니다
+func (m *Map) Get(key string) (any, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Set은 값을 설정합니다. 새 키는 끝에 추가되고 기존 키는 위치를 유지합니다.
+func (m *Map) Set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Delete는 키를 삭제합니다
+func (m *Map) Delete(key string) {
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Merge는 src의 값을 dst에 깊게 병합합니다.
+// 양쪽 모두 객체인 키는 재귀적으로 병합하고, 그 외의 값(배열 포함)은 src 값으로 덮어씁니다.
+func Merge(dst, src *Map) {
+	for _, key := range src.keys {
+		srcValue := src.values[key]
+		if srcMap, ok := srcValue.(*Map); ok {
+			if dstMap, ok := dst.values[key].(*Map); ok {
+				Merge(dstMap, srcMap)
+				continue
+			}
+			srcValue = srcMap.clone()
+		}
+		dst.Set(key, srcValue)
+	}
+}
+
+// Contains는 sub의 모든 값이 doc에 같은 값으로 들어 있는지 확인합니다
+func Contains(doc, sub *Map) bool {
+	for _, key := range sub.keys {
+		docValue, ok := doc.values[key]
+		if !ok {
+			return false
+		}
+		if subMap, ok := sub.values[key].(*Map); ok {
+			docMap, ok := docValue.(*Map)
+			if !ok || !Contains(docMap, subMap) {
+				return false
+			}
+			continue
+		}
+		if !Equal(docValue, sub.values[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Lookup은 경로를 따라 객체를 내려가 값을 찾습니다
+func Lookup(m *Map, path []string) (any, bool) {
+	var value any = m
+	for _, key := range path {
+		current, ok := value.(*Map)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = current.values[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// SetPath는 경로의 값을 설정합니다. 중간 객체가 없거나 객체가 아니면 새 객체로 만듭니다.
+func SetPath(m *Map, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := m.values[key].(*Map)
+		if !ok {
+			child = NewMap()
+			m.Set(key, child)
+		}
+		m = child
+	}
+	m.Set(path[len(path)-1], value)
+}
+
+// DeletePath는 경로의 키를 삭제합니다. 중간 객체가 없으면 아무것도 하지 않습니다.
+func DeletePath(m *Map, path []string) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := m.values[key].(*Map)
+		if !ok {
+			return
+		}
+		m = child
+	}
+	m.Delete(path[len(path)-1])
+}
+
+// Equal은 두 값이 같은지 비교합니다. 객체는 키 순서와 관계없이 비교합니다.
+func Equal(a, b any) bool {
+	switch av := a.(type) {
+	case *Map:
+		bv, ok := b.(*Map)
+		if !ok || av.Len() != bv.Len() {
+			return false
+		}
+		for _, key := range av.keys {
+			value, ok := bv.values[key]
+			if !ok || !Equal(av.values[key], value) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !Equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// clone은 객체를 깊게 복사합니다
+func (m *Map) clone() *Map {
+	result := NewMap()
+	for _, key := range m.keys {
+		value := m.values[key]
+		if child, ok := value.(*Map); ok {
+			value = child.clone()
+		}
+		result.Set(key, value)
+	}
+	return result
+}
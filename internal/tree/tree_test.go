@@ -0,0 +1,381 @@
+package tree
+
+import "testing"
+
+func TestJSON_RoundTripPreservesOrder(t *testing.T) {
+	input := "{\n  \"z\": 1,\n  \"a\": [true, null, \"<b>\"],\n  \"m\": {\n    \"y\": 1.50,\n    \"x\": {}\n  }\n}\n"
+
+	m, err := ParseJSON(input)
+	if err != nil {
+		t.Fatalf("JSON 파싱 실패: %v", err)
+	}
+
+	if output := EncodeJSON(m, "  "); output != "{\n  \"z\": 1,\n  \"a\": [\n    true,\n    null,\n    \"<b>\"\n  ],\n  \"m\": {\n    \"y\": 1.50,\n    \"x\": {}\n  }\n}\n" {
+		t.Errorf("키 순서와 값이 보존되어야 합니다:\n%s", output)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	dst, _ := ParseJSON(`{"a": {"b": 1, "c": [1, 2]}, "d": true}`)
+	src, _ := ParseJSON(`{"a": {"c": [3], "e": "x"}, "f": null}`)
+
+	Merge(dst, src)
+
+	expected, _ := ParseJSON(`{"a": {"b": 1, "c": [3], "e": "x"}, "d": true, "f": null}`)
+	if !Equal(dst, expected) {
+		t.Errorf("병합 결과가 일치하지 않습니다:\n%s", EncodeJSON(dst, "  "))
+	}
+	if !Contains(dst, src) {
+		t.Error("병합 후에는 src 값을 모두 포함해야 합니다")
+	}
+
+}
+
+func TestPathHelpers(t *testing.T) {
+	m, _ := ParseJSON(`{"a": {"b": 1}, "c": true}`)
+
+	if value, ok := Lookup(m, []string{"a", "b"}); !ok || value != Number("1") {
+		t.Errorf("a.b를 찾지 못했습니다: %v, %v", value, ok)
+	}
+	if _, ok := Lookup(m, []string{"c", "d"}); ok {
+		t.Error("객체가 아닌 값 아래는 찾을 수 없어야 합니다")
+	}
+
+	SetPath(m, []string{"x", "y"}, "z")
+	DeletePath(m, []string{"a", "b"})
+	DeletePath(m, []string{"missing", "key"})
+
+	expected, _ := ParseJSON(`{"a": {}, "c": true, "x": {"y": "z"}}`)
+	if !Equal(m, expected) {
+		t.Errorf("경로 수정 결과가 일치하지 않습니다:\n%s", EncodeJSON(m, "  "))
+	}
+}
+
+func TestParseJSON_RejectsNonObject(t *testing.T) {
+	for _, input := range []string{`[1, 2]`, `"text"`, `{"a": 1} {"b": 2}`, `{"a": }`} {
+		if _, err := ParseJSON(input); err == nil {
+			t.Errorf("%s는 오류를 반환해야 합니다", input)
+		}
+	}
+}
+
+func TestYAML_RoundTrip(t *testing.T) {
+	input := `# aider 설정
+model: gpt-4o   # 기본 모델
+auto-commits: false
+read:
+  - CONVENTIONS.md
+  - "docs/*.md"
+lint-cmd:
+- "go: go vet ./..."
+env:
+  GOFLAGS: -mod=mod
+  retries: 3
+  empty: ~
+rules:
+  - name: errors
+    prompt: |
+      에러는 감싸서 반환하세요.
+      fmt.Errorf를 사용하세요.
+  - name: tests
+    enabled: true
+flow: {a: 1, b: [x, "y z"]}
+`
+
+	m, err := ParseYAML(input)
+	if err != nil {
+		t.Fatalf("YAML 파싱 실패: %v", err)
+	}
+
+	expected := `model: gpt-4o
+auto-commits: false
+read:
+  - CONVENTIONS.md
+  - docs/*.md
+lint-cmd:
+  - "go: go vet ./..."
+env:
+  GOFLAGS: -mod=mod
+  retries: 3
+  empty: null
+rules:
+  - name: errors
+    prompt: |
+      에러는 감싸서 반환하세요.
+      fmt.Errorf를 사용하세요.
+  - name: tests
+    enabled: true
+flow:
+  a: 1
+  b:
+    - x
+    - y z
+`
+	output := EncodeYAML(m)
+	if output != expected {
+		t.Errorf("YAML 출력이 일치하지 않습니다.\n예상:\n%s\n실제:\n%s", expected, output)
+	}
+
+	// 출력한 YAML을 다시 읽어도 같은 값이어야 함
+	again, err := ParseYAML(output)
+	if err != nil {
+		t.Fatalf("출력한 YAML 파싱 실패: %v", err)
+	}
+	if !Equal(m, again) {
+		t.Error("다시 읽은 YAML 값이 일치하지 않습니다")
+	}
+}
+
+func TestParseYAML_Errors(t *testing.T) {
+	for _, input := range []string{"- a\n- b\n", "a: 1\n  b: 2\n", "a: &anchor 1\n", "a: 1\na: 2\n"} {
+		if _, err := ParseYAML(input); err == nil {
+			t.Errorf("%q는 오류를 반환해야 합니다", input)
+		}
+	}
+}
+
+func TestTOML_RoundTrip(t *testing.T) {
+	input := `# 설정
+title = "aide"
+"quoted key" = 'literal \n'
+dotted.key = 1_000
+when = 1979-05-27 07:32:00
+list = [
+  1,
+  2, # 주석
+]
+
+[server]
+host = "localhost"
+ports = [8000, 8001]
+inline = { a = true, b = { c = 1.5 } }
+
+[[rules]]
+name = "errors"
+prompt = """
+에러는 감싸서 \
+반환하세요."""
+
+[[rules]]
+name = "tests"
+`
+
+	m, err := ParseTOML(input)
+	if err != nil {
+		t.Fatalf("TOML 파싱 실패: %v", err)
+	}
+
+	output, err := EncodeTOML(m)
+	if err != nil {
+		t.Fatalf("TOML 출력 실패: %v", err)
+	}
+
+	expected := `title = "aide"
+"quoted key" = "literal \\n"
+when = 1979-05-27 07:32:00
+list = [1, 2]
+
+[dotted]
+key = 1_000
+
+[server]
+host = "localhost"
+ports = [8000, 8001]
+
+[server.inline]
+a = true
+
+[server.inline.b]
+c = 1.5
+
+[[rules]]
+name = "errors"
+prompt = "에러는 감싸서 반환하세요."
+
+[[rules]]
+name = "tests"
+`
+	if output != expected {
+		t.Errorf("TOML 출력이 일치하지 않습니다.\n예상:\n%s\n실제:\n%s", expected, output)
+	}
+
+	again, err := ParseTOML(output)
+	if err != nil {
+		t.Fatalf("출력한 TOML 파싱 실패: %v", err)
+	}
+	if !Equal(m, again) {
+		t.Error("다시 읽은 TOML 값이 일치하지 않습니다")
+	}
+}
+
+func TestTOML_Errors(t *testing.T) {
+	for _, input := range []string{"a = \n", "a = 1\na = 2\n", "a = \"x\n", "[a\n", "a = 1 b = 2\n"} {
+		if _, err := ParseTOML(input); err == nil {
+			t.Errorf("%q는 오류를 반환해야 합니다", input)
+		}
+	}
+
+	m, _ := ParseJSON(`{"a": null}`)
+	if _, err := EncodeTOML(m); err == nil {
+		t.Error("null 값은 TOML로 출력할 수 없어야 합니다")
+	}
+}
+
+func TestPatchYAML_PreservesCommentsAndFlowStyle(t *testing.T) {
+	input := `# my aider config
+model: gpt-4o  # main model
+roles: [chat, edit]
+read:
+  - CONVENTIONS.md # always read
+editor:
+  # theme settings
+  theme: dark
+  font: mono
+drop: true
+`
+	doc, err := ParseYAML(input)
+	if err != nil {
+		t.Fatalf("YAML 파싱 실패: %v", err)
+	}
+	doc.Set("model", "claude")
+	doc.Set("roles", []any{"chat", "edit", "review"})
+	editor, _ := doc.Get("editor")
+	editor.(*Map).Set("theme", "light")
+	editor.(*Map).Set("size", Number("12"))
+	doc.Delete("drop")
+	doc.Set("auto-commits", false)
+
+	output, err := PatchYAML(input, doc, nil)
+	if err != nil {
+		t.Fatalf("YAML 수정 실패: %v", err)
+	}
+
+	expected := `# my aider config
+model: claude  # main model
+roles: [chat, edit, review]
+read:
+  - CONVENTIONS.md # always read
+editor:
+  # theme settings
+  theme: light
+  font: mono
+  size: 12
+auto-commits: false
+`
+	if output != expected {
+		t.Errorf("바뀐 키만 고쳐 써야 합니다:\n%s", output)
+	}
+
+	again, err := ParseYAML(output)
+	if err != nil {
+		t.Fatalf("수정한 YAML 파싱 실패: %v", err)
+	}
+	if !Equal(doc, again) {
+		t.Error("다시 읽은 YAML 값이 일치하지 않습니다")
+	}
+
+	if unchanged, _ := PatchYAML(input, mustParseYAML(t, input), nil); unchanged != input {
+		t.Errorf("값이 같으면 원본을 그대로 유지해야 합니다:\n%s", unchanged)
+	}
+}
+
+func TestPatchTOML_PreservesCommentsAndFormatting(t *testing.T) {
+	input := `# top comment
+model = "gpt-4o" # main model
+roles = [ "chat", "edit" ]
+
+# editor settings
+[editor]
+theme = 'dark'
+font = "mono"
+
+[old]
+x = 1
+`
+	doc, err := ParseTOML(input)
+	if err != nil {
+		t.Fatalf("TOML 파싱 실패: %v", err)
+	}
+	doc.Set("model", "claude")
+	editor, _ := doc.Get("editor")
+	editor.(*Map).Set("theme", "light")
+	editor.(*Map).Set("size", Number("12"))
+	doc.Delete("old")
+	doc.Set("auto-commits", false)
+	mcp := NewMap()
+	mcp.Set("command", "npx")
+	doc.Set("mcp", mcp)
+
+	output, err := PatchTOML(input, doc, nil)
+	if err != nil {
+		t.Fatalf("TOML 수정 실패: %v", err)
+	}
+
+	expected := `# top comment
+model = "claude" # main model
+roles = [ "chat", "edit" ]
+auto-commits = false
+
+# editor settings
+[editor]
+theme = "light"
+font = "mono"
+size = 12
+
+[mcp]
+command = "npx"
+`
+	if output != expected {
+		t.Errorf("바뀐 키만 고쳐 써야 합니다:\n%s", output)
+	}
+
+	again, err := ParseTOML(output)
+	if err != nil {
+		t.Fatalf("수정한 TOML 파싱 실패: %v", err)
+	}
+	if !Equal(doc, again) {
+		t.Error("다시 읽은 TOML 값이 일치하지 않습니다")
+	}
+}
+
+func TestPatchTOML_DottedAndImplicitTables(t *testing.T) {
+	input := "[tool.lint]\nenabled = true\n"
+	doc, _ := ParseTOML(input)
+	tool, _ := doc.Get("tool")
+	tool.(*Map).Set("name", "aide")
+
+	output, err := PatchTOML(input, doc, nil)
+	if err != nil {
+		t.Fatalf("TOML 수정 실패: %v", err)
+	}
+	if output != "tool.name = \"aide\"\n\n[tool.lint]\nenabled = true\n" {
+		t.Errorf("암시적 테이블의 새 키는 점 키로 추가해야 합니다:\n%s", output)
+	}
+	if again, err := ParseTOML(output); err != nil || !Equal(doc, again) {
+		t.Errorf("다시 읽은 TOML 값이 일치하지 않습니다: %v", err)
+	}
+}
+
+func mustParseYAML(t *testing.T, input string) *Map {
+	t.Helper()
+	m, err := ParseYAML(input)
+	if err != nil {
+		t.Fatalf("YAML 파싱 실패: %v", err)
+	}
+	return m
+}
+
+func TestEncodeYAML_LeadingSpaceString(t *testing.T) {
+	doc := NewMap()
+	doc.Set("indented", "  theme: dark\n  font: mono\n")
+	doc.Set("block", "line one\nline two\n")
+
+	output := EncodeYAML(doc)
+	again, err := ParseYAML(output)
+	if err != nil {
+		t.Fatalf("출력한 YAML 파싱 실패: %v\n%s", err, output)
+	}
+	if !Equal(doc, again) {
+		t.Errorf("공백으로 시작하는 여러 줄 문자열도 그대로 다시 읽혀야 합니다:\n%s", output)
+	}
+}
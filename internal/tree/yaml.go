@@ -0,0 +1,841 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// YAML은 설정 파일에서 흔히 쓰는 부분집합만 지원합니다.
+// 블록 매핑/시퀀스, 따옴표 문자열, 블록 스칼라(| >), 한 줄짜리 흐름 컬렉션([a, b], {a: 1})을 다루며
+// 앵커, 별칭, 태그, 여러 줄에 걸친 일반 스칼라는 지원하지 않습니다.
+// 기존 문서를 고칠 때는 PatchYAML로 바뀐 키만 다시 써서 주석과 서식을 유지합니다.
+
+// yamlNumber는 YAML 1.2 core 스키마의 숫자 표기입니다
+var yamlNumber = regexp.MustCompile(`^[-+]?(\d+|\d*\.\d+|\d+\.\d*)([eE][-+]?\d+)?$|^0x[0-9a-fA-F]+$|^0o[0-7]+$|^[-+]?\.(inf|Inf|INF)$|^\.(nan|NaN|NAN)$`)
+
+// yamlParser는 줄 단위 YAML 파서입니다
+type yamlParser struct {
+	lines []string
+	pos   int
+
+	// spans는 블록 매핑마다 키가 차지하는 줄 위치입니다 (PatchYAML에서만 기록)
+	spans map[*Map]*yamlSpan
+}
+
+// yamlSpan은 블록 매핑 하나의 원본 위치입니다
+type yamlSpan struct {
+	indent  int                   // 키의 들여쓰기
+	end     int                   // 마지막 항목 다음 줄 번호
+	entries map[string]*yamlEntry // 키별 위치
+}
+
+// yamlEntry는 매핑 항목 하나의 원본 위치입니다
+type yamlEntry struct {
+	line, end int // 키가 있는 줄과 값이 끝난 다음 줄 번호
+
+	// 값이 키와 같은 줄에 있으면 그 열 범위 (주석 제외)
+	inline           bool
+	valStart, valEnd int
+	flow             bool // 흐름 컬렉션([a, b], {a: 1})인지 여부
+}
+
+// ParseYAML은 YAML 매핑 문서를 키 순서를 보존하여 파싱합니다
+func ParseYAML(data string) (*Map, error) {
+	return parseYAML(&yamlParser{lines: strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")})
+}
+
+// parseYAML은 파서의 줄을 문서 하나로 파싱합니다
+func parseYAML(p *yamlParser) (*Map, error) {
+	// 문서 시작 표시 건너뛰기
+	if p.skipBlank() && strings.TrimSpace(p.lines[p.pos]) == "---" {
+		p.pos++
+	}
+
+	if !p.skipBlank() {
+		return NewMap(), nil
+	}
+
+	value, err := p.parseBlock(indentOf(p.lines[p.pos]))
+	if err != nil {
+		return nil, fmt.Errorf("올바른 YAML이 아닙니다: %w", err)
+	}
+
+	if p.skipBlank() && strings.TrimSpace(p.lines[p.pos]) != "..." {
+		return nil, fmt.Errorf("올바른 YAML이 아닙니다: %d번째 줄의 들여쓰기가 잘못되었습니다", p.pos+1)
+	}
+
+	m, ok := value.(*Map)
+	if !ok {
+		return nil, fmt.Errorf("YAML 최상위 값은 매핑이어야 합니다")
+	}
+	return m, nil
+}
+
+// skipBlank는 빈 줄과 주석 줄을 건너뛰고, 남은 줄이 있으면 true를 반환합니다
+func (p *yamlParser) skipBlank() bool {
+	for p.pos < len(p.lines) {
+		trimmed := strings.TrimSpace(p.lines[p.pos])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return true
+		}
+		p.pos++
+	}
+	return false
+}
+
+// indentOf는 줄의 들여쓰기 칸 수를 반환합니다
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// isSeqItem은 내용이 시퀀스 항목인지 확인합니다
+func isSeqItem(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+// parseBlock은 현재 위치에서 지정한 들여쓰기의 블록 매핑 또는 시퀀스를 파싱합니다
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	if isSeqItem(strings.TrimSpace(p.lines[p.pos])) {
+		return p.parseSeq(indent)
+	}
+	return p.parseMap(indent)
+}
+
+// parseMap은 블록 매핑을 파싱합니다
+func (p *yamlParser) parseMap(indent int) (*Map, error) {
+	m := NewMap()
+	span := &yamlSpan{indent: indent, entries: make(map[string]*yamlEntry)}
+	if p.spans != nil {
+		p.spans[m] = span
+	}
+	for p.skipBlank() {
+		line := p.lines[p.pos]
+		ind := indentOf(line)
+		if ind < indent {
+			break
+		}
+		if ind > indent {
+			return nil, fmt.Errorf("%d번째 줄의 들여쓰기가 잘못되었습니다", p.pos+1)
+		}
+
+		content := stripYAMLComment(strings.TrimSpace(line))
+		if isSeqItem(content) {
+			break
+		}
+
+		key, rest, err := splitYAMLKey(content)
+		if err != nil {
+			return nil, fmt.Errorf("%d번째 줄: %w", p.pos+1, err)
+		}
+		if _, exists := m.Get(key); exists {
+			return nil, fmt.Errorf("%d번째 줄: 키 '%s'가 중복되었습니다", p.pos+1, key)
+		}
+		entry := &yamlEntry{line: p.pos}
+		if rest != "" && rest[0] != '|' && rest[0] != '>' {
+			entry.inline = true
+			entry.valEnd = ind + len(content)
+			entry.valStart = entry.valEnd - len(rest)
+			entry.flow = rest[0] == '[' || rest[0] == '{'
+		}
+		p.pos++
+
+		value, err := p.parseValue(rest, indent, true)
+		if err != nil {
+			return nil, err
+		}
+		m.Set(key, value)
+
+		// 값 뒤의 빈 줄과 주석 줄은 다음 키에 속함 (블록 스칼라의 #은 내용)
+		entry.end = p.pos
+		_, scalar := value.(string)
+		for entry.end > entry.line+1 {
+			trimmed := strings.TrimSpace(p.lines[entry.end-1])
+			if trimmed != "" && (scalar || !strings.HasPrefix(trimmed, "#")) {
+				break
+			}
+			entry.end--
+		}
+		span.entries[key] = entry
+		span.end = entry.end
+	}
+	return m, nil
+}
+
+// parseSeq는 블록 시퀀스를 파싱합니다
+func (p *yamlParser) parseSeq(indent int) ([]any, error) {
+	list := []any{}
+	for p.skipBlank() {
+		line := p.lines[p.pos]
+		ind := indentOf(line)
+		content := strings.TrimSpace(line)
+		if ind != indent || !isSeqItem(content) {
+			if ind > indent {
+				return nil, fmt.Errorf("%d번째 줄의 들여쓰기가 잘못되었습니다", p.pos+1)
+			}
+			break
+		}
+
+		rest := strings.TrimLeft(strings.TrimPrefix(content, "-"), " ")
+		stripped := stripYAMLComment(rest)
+
+		// "- key: value" 형태는 같은 줄에서 시작하는 매핑
+		if _, _, err := splitYAMLKey(stripped); err == nil && !strings.HasPrefix(stripped, "[") && !strings.HasPrefix(stripped, "{") && !isQuoted(stripped) {
+			column := ind + len(content) - len(rest)
+			p.lines[p.pos] = strings.Repeat(" ", column) + rest
+			item, err := p.parseMap(column)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+			continue
+		}
+
+		p.pos++
+		item, err := p.parseValue(stripped, indent, false)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+// parseValue는 "키:" 또는 "-" 뒤의 값을 파싱합니다. 값이 비어 있으면 다음 줄의 블록을 읽습니다.
+func (p *yamlParser) parseValue(rest string, indent int, allowSameIndentSeq bool) (any, error) {
+	if strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">") {
+		return p.parseBlockScalar(rest, indent)
+	}
+	if strings.HasPrefix(rest, "&") || strings.HasPrefix(rest, "*") || strings.HasPrefix(rest, "!") {
+		return nil, fmt.Errorf("%d번째 줄: 앵커, 별칭, 태그는 지원하지 않습니다", p.pos)
+	}
+	if rest != "" {
+		return parseYAMLFlow(rest)
+	}
+
+	if !p.skipBlank() {
+		return nil, nil
+	}
+	next := p.lines[p.pos]
+	nextIndent := indentOf(next)
+	switch {
+	case nextIndent > indent:
+		return p.parseBlock(nextIndent)
+	case nextIndent == indent && allowSameIndentSeq && isSeqItem(strings.TrimSpace(next)):
+		// 키와 같은 들여쓰기의 시퀀스
+		return p.parseSeq(indent)
+	default:
+		return nil, nil
+	}
+}
+
+// parseBlockScalar는 | 또는 > 블록 스칼라를 파싱합니다
+func (p *yamlParser) parseBlockScalar(header string, indent int) (string, error) {
+	style, chomp := header[0], byte(0)
+	if len(header) > 1 {
+		chomp = header[1]
+		if (chomp != '-' && chomp != '+') || len(strings.TrimSpace(stripYAMLComment(header[2:]))) > 0 {
+			return "", fmt.Errorf("%d번째 줄: 지원하지 않는 블록 스칼라 표시입니다: %s", p.pos, header)
+		}
+	}
+
+	// 내용 줄 모으기
+	var lines []string
+	contentIndent := -1
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+		ind := indentOf(line)
+		if ind <= indent || (contentIndent >= 0 && ind < contentIndent) {
+			break
+		}
+		if contentIndent < 0 {
+			contentIndent = ind
+		}
+		lines = append(lines, line[contentIndent:])
+		p.pos++
+	}
+
+	// 끝쪽 빈 줄은 chomping 규칙에 따라 처리
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	var text string
+	if style == '|' {
+		text = strings.Join(lines, "\n")
+	} else {
+		text = foldYAMLLines(lines)
+	}
+
+	switch chomp {
+	case '-':
+		return text, nil
+	case '+':
+		return text + strings.Repeat("\n", trailing+1), nil
+	default:
+		return text + "\n", nil
+	}
+}
+
+// foldYAMLLines는 > 블록 스칼라의 줄을 접습니다
+func foldYAMLLines(lines []string) string {
+	var out strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			if line == "" || lines[i-1] == "" || strings.HasPrefix(line, " ") {
+				out.WriteString("\n")
+			} else {
+				out.WriteString(" ")
+			}
+		}
+		out.WriteString(line)
+	}
+	return out.String()
+}
+
+// splitYAMLKey는 "키: 값" 형태의 줄을 키와 값으로 나눕니다
+func splitYAMLKey(content string) (string, string, error) {
+	if isQuoted(content) {
+		end := closingQuote(content)
+		if end < 0 {
+			return "", "", fmt.Errorf("따옴표가 닫히지 않았습니다")
+		}
+		after := content[end+1:]
+		if !strings.HasPrefix(after, ":") {
+			return "", "", fmt.Errorf("매핑 키가 아닙니다")
+		}
+		key, err := parseYAMLScalar(content[:end+1])
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprint(key), strings.TrimSpace(after[1:]), nil
+	}
+
+	for i := 0; i < len(content); i++ {
+		if content[i] == ':' && (i == len(content)-1 || content[i+1] == ' ') {
+			key := strings.TrimSpace(content[:i])
+			if key == "" {
+				return "", "", fmt.Errorf("매핑 키가 비어 있습니다")
+			}
+			return key, strings.TrimSpace(content[i+1:]), nil
+		}
+	}
+	return "", "", fmt.Errorf("매핑 키가 아닙니다: %s", content)
+}
+
+// isQuoted는 내용이 따옴표로 시작하는지 확인합니다
+func isQuoted(content string) bool {
+	return strings.HasPrefix(content, `"`) || strings.HasPrefix(content, "'")
+}
+
+// closingQuote는 첫 글자로 시작한 따옴표 문자열의 닫는 위치를 반환합니다
+func closingQuote(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment는 따옴표 밖의 " #" 뒤 주석을 제거합니다
+func stripYAMLComment(s string) string {
+	if strings.HasPrefix(s, "#") {
+		return ""
+	}
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if quote == '"' && c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.ContainsRune(" [{,:", rune(s[i-1])) {
+				quote = c
+			}
+		case c == '#' && i > 0 && (s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimSpace(s[:i])
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// parseYAMLFlow는 한 줄짜리 값(스칼라 또는 흐름 컬렉션)을 파싱합니다
+func parseYAMLFlow(s string) (any, error) {
+	f := &yamlFlow{s: s}
+	value, err := f.value()
+	if err != nil {
+		return nil, err
+	}
+	f.skipSpace()
+	if f.pos != len(f.s) {
+		return nil, fmt.Errorf("예상하지 못한 내용입니다: %s", f.s[f.pos:])
+	}
+	return value, nil
+}
+
+// yamlFlow는 흐름 컬렉션 파서입니다
+type yamlFlow struct {
+	s   string
+	pos int
+}
+
+func (f *yamlFlow) skipSpace() {
+	for f.pos < len(f.s) && (f.s[f.pos] == ' ' || f.s[f.pos] == '\t') {
+		f.pos++
+	}
+}
+
+// value는 값 하나를 읽습니다. 흐름 컬렉션 안에서는 쉼표와 닫는 괄호에서 멈춥니다.
+func (f *yamlFlow) value() (any, error) {
+	f.skipSpace()
+	if f.pos >= len(f.s) {
+		return nil, nil
+	}
+
+	switch f.s[f.pos] {
+	case '[':
+		f.pos++
+		list := []any{}
+		for {
+			f.skipSpace()
+			if f.pos < len(f.s) && f.s[f.pos] == ']' {
+				f.pos++
+				return list, nil
+			}
+			item, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+			if err := f.separator(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.pos++
+		m := NewMap()
+		for {
+			f.skipSpace()
+			if f.pos < len(f.s) && f.s[f.pos] == '}' {
+				f.pos++
+				return m, nil
+			}
+			key, err := f.scalar(":")
+			if err != nil {
+				return nil, err
+			}
+			if f.pos >= len(f.s) || f.s[f.pos] != ':' {
+				return nil, fmt.Errorf("흐름 매핑에 ':'이 없습니다: %s", f.s)
+			}
+			f.pos++
+			value, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			m.Set(fmt.Sprint(key), value)
+			if err := f.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return f.scalar(",]}")
+	}
+}
+
+// separator는 쉼표 또는 닫는 괄호를 확인합니다. 닫는 괄호는 소비하지 않습니다.
+func (f *yamlFlow) separator(closing byte) error {
+	f.skipSpace()
+	if f.pos >= len(f.s) {
+		return fmt.Errorf("흐름 컬렉션이 닫히지 않았습니다: %s", f.s)
+	}
+	switch f.s[f.pos] {
+	case ',':
+		f.pos++
+		return nil
+	case closing:
+		return nil
+	default:
+		return fmt.Errorf("예상하지 못한 문자입니다: %q", f.s[f.pos])
+	}
+}
+
+// scalar는 따옴표 또는 일반 스칼라를 읽습니다. 흐름 컬렉션 안에서는 stops 문자에서 멈춥니다.
+func (f *yamlFlow) scalar(stops string) (any, error) {
+	rest := f.s[f.pos:]
+	if isQuoted(rest) {
+		end := closingQuote(rest)
+		if end < 0 {
+			return nil, fmt.Errorf("따옴표가 닫히지 않았습니다: %s", rest)
+		}
+		f.pos += end + 1
+		return parseYAMLScalar(rest[:end+1])
+	}
+
+	// 최상위 일반 스칼라는 줄 끝까지
+	nested := f.pos > 0 || strings.HasPrefix(stops, ":")
+	end := len(rest)
+	if nested {
+		for i := 0; i < len(rest); i++ {
+			if strings.IndexByte(stops, rest[i]) >= 0 {
+				end = i
+				break
+			}
+		}
+	}
+	f.pos += end
+	return parseYAMLScalar(strings.TrimSpace(rest[:end]))
+}
+
+// parseYAMLScalar는 스칼라 하나를 해석합니다
+func parseYAMLScalar(s string) (any, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		var value string
+		if err := json.Unmarshal([]byte(s), &value); err != nil {
+			return nil, fmt.Errorf("잘못된 문자열입니다: %s", s)
+		}
+		return value, nil
+	case strings.HasPrefix(s, "'"):
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if yamlNumber.MatchString(s) {
+		return Number(s), nil
+	}
+	return s, nil
+}
+
+// EncodeYAML은 Map을 YAML 블록 형식으로 변환합니다
+func EncodeYAML(m *Map) string {
+	if m.Len() == 0 {
+		return "{}\n"
+	}
+	var out strings.Builder
+	writeYAMLMap(&out, m, 0)
+	return out.String()
+}
+
+// writeYAMLMap은 매핑을 지정한 들여쓰기로 출력합니다
+func writeYAMLMap(out *strings.Builder, m *Map, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, key := range m.keys {
+		out.WriteString(pad)
+		out.WriteString(quoteYAML(key))
+		out.WriteString(":")
+		writeYAMLValue(out, m.values[key], indent)
+	}
+}
+
+// writeYAMLValue는 "키:" 또는 "-" 뒤에 올 값을 출력합니다
+func writeYAMLValue(out *strings.Builder, value any, indent int) {
+	switch v := value.(type) {
+	case *Map:
+		if v.Len() == 0 {
+			out.WriteString(" {}\n")
+			return
+		}
+		out.WriteString("\n")
+		writeYAMLMap(out, v, indent+2)
+	case []any:
+		if len(v) == 0 {
+			out.WriteString(" []\n")
+			return
+		}
+		out.WriteString("\n")
+		writeYAMLSeq(out, v, indent+2)
+	case string:
+		// 첫 내용 줄이 공백으로 시작하면 블록 스칼라의 들여쓰기로 읽히므로 따옴표로 출력
+		if strings.Contains(v, "\n") && !strings.HasPrefix(strings.TrimLeft(v, "\n"), " ") {
+			writeYAMLBlockScalar(out, v, indent+2)
+			return
+		}
+		out.WriteString(" ")
+		out.WriteString(quoteYAML(v))
+		out.WriteString("\n")
+	default:
+		out.WriteString(" ")
+		out.WriteString(formatYAMLScalar(v))
+		out.WriteString("\n")
+	}
+}
+
+// writeYAMLSeq는 시퀀스를 지정한 들여쓰기로 출력합니다
+func writeYAMLSeq(out *strings.Builder, list []any, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, item := range list {
+		if m, ok := item.(*Map); ok && m.Len() > 0 {
+			// 매핑의 첫 키를 "- " 뒤에 이어서 출력
+			var nested strings.Builder
+			writeYAMLMap(&nested, m, indent+2)
+			out.WriteString(pad + "- ")
+			out.WriteString(nested.String()[indent+2:])
+			continue
+		}
+		out.WriteString(pad + "-")
+		writeYAMLValue(out, item, indent)
+	}
+}
+
+// writeYAMLBlockScalar는 여러 줄 문자열을 | 블록 스칼라로 출력합니다
+func writeYAMLBlockScalar(out *strings.Builder, s string, indent int) {
+	header := " |-"
+	if strings.HasSuffix(s, "\n") {
+		header = " |"
+		s = strings.TrimSuffix(s, "\n")
+	}
+	if strings.HasSuffix(s, "\n") {
+		header = " |+"
+	}
+	out.WriteString(header + "\n")
+
+	pad := strings.Repeat(" ", indent)
+	for _, line := range strings.Split(s, "\n") {
+		if line != "" {
+			out.WriteString(pad)
+			out.WriteString(line)
+		}
+		out.WriteString("\n")
+	}
+}
+
+// formatYAMLScalar는 문자열이 아닌 스칼라를 출력 형식으로 변환합니다
+func formatYAMLScalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case Number:
+		return string(v)
+	default:
+		return quoteYAML(fmt.Sprint(v))
+	}
+}
+
+// quoteYAML은 필요한 경우에만 문자열을 따옴표로 감쌉니다
+func quoteYAML(s string) string {
+	needsQuote := s == "" ||
+		strings.ContainsAny(s[:1], ",[]{}#&*!|>'\"%@` \t") ||
+		(strings.ContainsAny(s[:1], "-?:") && (len(s) == 1 || s[1] == ' ')) ||
+		strings.HasSuffix(s, " ") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") ||
+		strings.ContainsAny(s, "\n\r\t")
+
+	if !needsQuote {
+		// 따옴표 없이 쓰면 다른 타입으로 해석되는 경우
+		if value, _ := parseYAMLScalar(s); value != s {
+			needsQuote = true
+		}
+	}
+
+	if !needsQuote {
+		return s
+	}
+	return quoteJSON(s)
+}
+
+// PatchYAML은 기존 YAML 문서 source를 doc과 같은 값이 되도록 고칩니다.
+// 바뀐 키만 다시 쓰고 나머지 줄은 그대로 두므로 주석, 흐름 컬렉션, 따옴표 등 서식이 유지됩니다.
+// 같은 줄에 있던 값은 그 자리에서 바꾸며(흐름 컬렉션은 흐름 형식으로), 새 키는 매핑의 마지막 키 뒤에 추가합니다.
+// originals에 바뀐 키의 원본 항목 텍스트가 있고 같은 값을 나타내면 그 텍스트로 되돌립니다.
+func PatchYAML(source string, doc *Map, originals Originals) (string, error) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	if strings.TrimSpace(source) == "" {
+		return EncodeYAML(doc), nil
+	}
+
+	lines := strings.Split(source, "\n")
+	p := &yamlParser{lines: append([]string{}, lines...), spans: make(map[*Map]*yamlSpan)}
+	orig, err := parseYAML(p)
+	if err != nil {
+		return "", err
+	}
+
+	e := &yamlPatcher{editor: &sourceEditor{src: source}, lines: lines, offsets: lineOffsets(lines), spans: p.spans, originals: originals}
+	e.patchMap(orig, doc, p.spans[orig], nil)
+	return e.editor.String(), nil
+}
+
+// YAMLSource는 문서 source에서 path 키 항목이 차지하는 원본 텍스트(키 줄부터 값 끝까지)를 반환합니다.
+// 블록 매핑 안의 키만 찾을 수 있습니다.
+func YAMLSource(source string, path []string) (string, bool) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	lines := strings.Split(source, "\n")
+	p := &yamlParser{lines: append([]string{}, lines...), spans: make(map[*Map]*yamlSpan)}
+	m, err := parseYAML(p)
+	if err != nil || len(path) == 0 {
+		return "", false
+	}
+
+	offsets := lineOffsets(lines)
+	for i, key := range path {
+		span := p.spans[m]
+		if span == nil || span.entries[key] == nil {
+			return "", false
+		}
+		entry := span.entries[key]
+		if i == len(path)-1 {
+			return source[offsets[entry.line]:offsets[entry.end]], true
+		}
+		if m, _ = m.values[key].(*Map); m == nil || entry.inline {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// yamlPatcher는 원본 위치를 따라 YAML 문서를 고칩니다
+type yamlPatcher struct {
+	editor  *sourceEditor
+	lines   []string
+	offsets []int
+	spans   map[*Map]*yamlSpan
+
+	originals Originals
+}
+
+// patchMap은 원본 매핑 orig를 doc과 같아지도록 고칩니다. span이 없으면 빈 문서로 보고 끝에 추가합니다.
+// path는 문서 루트부터 orig까지의 키 경로입니다.
+func (e *yamlPatcher) patchMap(orig, doc *Map, span *yamlSpan, path []string) {
+	if span == nil {
+		span = &yamlSpan{end: len(e.lines)}
+	}
+
+	for _, key := range orig.keys {
+		entry := span.entries[key]
+		value, ok := doc.values[key]
+		start, end := e.offsets[entry.line], e.offsets[entry.end]
+		keyPath := append(append([]string{}, path...), key)
+		switch {
+		case !ok:
+			e.editor.replace(start, end, "")
+		case Equal(orig.values[key], value):
+		case e.patchChild(orig.values[key], value, entry, keyPath):
+		case e.restoreOriginal(keyPath, value, start, end):
+		case entry.inline && (entry.flow || !isCollection(value)):
+			// 같은 줄의 값만 바꾸고 키와 주석은 유지
+			e.editor.replace(start+entry.valStart, start+entry.valEnd, formatYAMLFlow(value, entry.flow))
+		default:
+			e.editor.replace(start, end, encodeYAMLEntry(key, value, span.indent))
+		}
+	}
+
+	var added strings.Builder
+	for _, key := range doc.keys {
+		if _, ok := orig.values[key]; !ok {
+			added.WriteString(encodeYAMLEntry(key, doc.values[key], span.indent))
+		}
+	}
+	if added.Len() > 0 {
+		e.editor.insert(e.offsets[span.end], added.String())
+	}
+}
+
+// patchChild는 양쪽 모두 블록 매핑인 값을 재귀적으로 고치고, 고쳤으면 true를 반환합니다
+func (e *yamlPatcher) patchChild(orig, value any, entry *yamlEntry, path []string) bool {
+	origMap, ok := orig.(*Map)
+	if !ok || entry.inline || e.spans[origMap] == nil {
+		return false
+	}
+	valueMap, ok := value.(*Map)
+	if !ok || valueMap.Len() == 0 {
+		return false
+	}
+	e.patchMap(origMap, valueMap, e.spans[origMap], path)
+	return true
+}
+
+// restoreOriginal은 path 항목의 원본 텍스트가 value를 나타내면 항목을 그 텍스트로 바꾸고 true를 반환합니다
+func (e *yamlPatcher) restoreOriginal(path []string, value any, start, end int) bool {
+	text, ok := e.originals.get(path)
+	if !ok {
+		return false
+	}
+	m, err := ParseYAML(text)
+	if err != nil || m.Len() != 1 {
+		return false
+	}
+	if original, ok := m.values[path[len(path)-1]]; !ok || !Equal(original, value) {
+		return false
+	}
+	e.editor.replace(start, end, text)
+	return true
+}
+
+// isCollection은 값이 매핑이나 시퀀스인지 확인합니다
+func isCollection(value any) bool {
+	switch value.(type) {
+	case *Map, []any:
+		return true
+	}
+	return false
+}
+
+// encodeYAMLEntry는 "키: 값" 항목 하나를 지정한 들여쓰기의 블록 형식으로 출력합니다
+func encodeYAMLEntry(key string, value any, indent int) string {
+	var out strings.Builder
+	entry := NewMap()
+	entry.Set(key, value)
+	writeYAMLMap(&out, entry, indent)
+	return out.String()
+}
+
+// formatYAMLFlow는 값을 한 줄짜리 흐름 형식으로 출력합니다.
+// nested이면 흐름 컬렉션 안에 쓰는 값이므로 쉼표와 괄호가 있는 문자열도 따옴표로 감쌉니다.
+func formatYAMLFlow(value any, nested bool) string {
+	switch v := value.(type) {
+	case *Map:
+		items := make([]string, 0, v.Len())
+		for _, key := range v.keys {
+			items = append(items, formatYAMLFlow(key, true)+": "+formatYAMLFlow(v.values[key], true))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, formatYAMLFlow(item, true))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case string:
+		quoted := quoteYAML(v)
+		if nested && quoted == v && strings.ContainsAny(v, ",[]{}") {
+			quoted = quoteJSON(v)
+		}
+		return quoted
+	default:
+		return formatYAMLScalar(v)
+	}
+}